@@ -0,0 +1,322 @@
+package util
+
+import "golang.org/x/exp/constraints"
+
+// BSTEntry 二叉搜索树节点中保存的键值对
+type BSTEntry[K constraints.Ordered, V any] struct {
+	Key   K
+	Value V
+	// height 子树高度，用于 AVL 平衡
+	height int
+	// size 子树节点数，用于 Rank/Select
+	size int
+}
+
+// BST 基于 TreeNode 的有序二叉搜索树，使用 AVL 算法保持平衡
+// 查找、插入、删除的时间复杂度都是 O(log n)
+type BST[K constraints.Ordered, V any] struct {
+	root *TreeNode[BSTEntry[K, V]]
+}
+
+// NewBST 新建一个空的二叉搜索树
+func NewBST[K constraints.Ordered, V any]() *BST[K, V] {
+	return &BST[K, V]{}
+}
+
+// Root 返回根节点，树为空时返回 nil
+// 返回的节点只应该用于遍历，修改节点会破坏树的有序性
+func (t *BST[K, V]) Root() *TreeNode[BSTEntry[K, V]] {
+	return t.root
+}
+
+// Len 返回树中键的个数
+func (t *BST[K, V]) Len() int {
+	return t.size(t.root)
+}
+
+// Empty 树是否为空
+func (t *BST[K, V]) Empty() bool {
+	return t.root == nil
+}
+
+// Height 返回树的高度，空树的高度为 0
+func (t *BST[K, V]) Height() int {
+	return t.height(t.root)
+}
+
+// Clear 清空树
+func (t *BST[K, V]) Clear() {
+	t.root = nil
+}
+
+// Insert 插入键值对，键已存在时覆盖原来的值
+func (t *BST[K, V]) Insert(k K, v V) {
+	t.root = t.insert(t.root, k, v)
+}
+
+// Delete 删除键，返回键是否存在
+func (t *BST[K, V]) Delete(k K) bool {
+	var ok bool
+	t.root, ok = t.delete(t.root, k)
+	return ok
+}
+
+// Get 返回键对应值的指针，键不存在时返回 nil
+func (t *BST[K, V]) Get(k K) *V {
+	n := t.root
+	for n != nil {
+		switch {
+		case k < n.val.Key:
+			n = n.left
+		case k > n.val.Key:
+			n = n.right
+		default:
+			return &n.val.Value
+		}
+	}
+	return nil
+}
+
+// Has 是否存在键
+func (t *BST[K, V]) Has(k K) bool {
+	return t.Get(k) != nil
+}
+
+// Min 返回最小的键，树为空时返回 nil
+func (t *BST[K, V]) Min() *K {
+	if t.root == nil {
+		return nil
+	}
+	key := t.min(t.root).val.Key
+	return &key
+}
+
+// Max 返回最大的键，树为空时返回 nil
+func (t *BST[K, V]) Max() *K {
+	if t.root == nil {
+		return nil
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	key := n.val.Key
+	return &key
+}
+
+// Floor 返回小于等于 k 的最大键，不存在时返回 nil
+func (t *BST[K, V]) Floor(k K) *K {
+	var ret *K
+	n := t.root
+	for n != nil {
+		if k < n.val.Key {
+			n = n.left
+			continue
+		}
+		key := n.val.Key
+		ret = &key
+		if k == key {
+			break
+		}
+		n = n.right
+	}
+	return ret
+}
+
+// Ceiling 返回大于等于 k 的最小键，不存在时返回 nil
+func (t *BST[K, V]) Ceiling(k K) *K {
+	var ret *K
+	n := t.root
+	for n != nil {
+		if k > n.val.Key {
+			n = n.right
+			continue
+		}
+		key := n.val.Key
+		ret = &key
+		if k == key {
+			break
+		}
+		n = n.left
+	}
+	return ret
+}
+
+// Rank 返回树中小于 k 的键的个数
+func (t *BST[K, V]) Rank(k K) int {
+	var rank int
+	n := t.root
+	for n != nil {
+		switch {
+		case k < n.val.Key:
+			n = n.left
+		case k > n.val.Key:
+			rank += t.size(n.left) + 1
+			n = n.right
+		default:
+			return rank + t.size(n.left)
+		}
+	}
+	return rank
+}
+
+// Select 返回第 i 小的键(从 0 开始)，与 Rank 互为逆操作
+// 下标越界时返回 nil
+func (t *BST[K, V]) Select(i int) *K {
+	if i < 0 || i >= t.Len() {
+		return nil
+	}
+	n := t.root
+	for n != nil {
+		ls := t.size(n.left)
+		switch {
+		case i < ls:
+			n = n.left
+		case i > ls:
+			i -= ls + 1
+			n = n.right
+		default:
+			key := n.val.Key
+			return &key
+		}
+	}
+	return nil
+}
+
+// Keys 按从小到大的顺序返回所有的键
+func (t *BST[K, V]) Keys() []K {
+	ret := make([]K, 0, t.Len())
+	var walk func(n *TreeNode[BSTEntry[K, V]])
+	walk = func(n *TreeNode[BSTEntry[K, V]]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		ret = append(ret, n.val.Key)
+		walk(n.right)
+	}
+	walk(t.root)
+	return ret
+}
+
+func (t *BST[K, V]) height(n *TreeNode[BSTEntry[K, V]]) int {
+	if n == nil {
+		return 0
+	}
+	return n.val.height
+}
+
+func (t *BST[K, V]) size(n *TreeNode[BSTEntry[K, V]]) int {
+	if n == nil {
+		return 0
+	}
+	return n.val.size
+}
+
+func (t *BST[K, V]) min(n *TreeNode[BSTEntry[K, V]]) *TreeNode[BSTEntry[K, V]] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// update 根据子树重新计算节点的高度与节点数
+func (t *BST[K, V]) update(n *TreeNode[BSTEntry[K, V]]) {
+	lh, rh := t.height(n.left), t.height(n.right)
+	if lh > rh {
+		n.val.height = lh + 1
+	} else {
+		n.val.height = rh + 1
+	}
+	n.val.size = t.size(n.left) + t.size(n.right) + 1
+}
+
+func (t *BST[K, V]) rotateLeft(n *TreeNode[BSTEntry[K, V]]) *TreeNode[BSTEntry[K, V]] {
+	r := n.right
+	n.SetRightNode(r.left)
+	r.SetLeftNode(n)
+	t.update(n)
+	t.update(r)
+	return r
+}
+
+func (t *BST[K, V]) rotateRight(n *TreeNode[BSTEntry[K, V]]) *TreeNode[BSTEntry[K, V]] {
+	l := n.left
+	n.SetLeftNode(l.right)
+	l.SetRightNode(n)
+	t.update(n)
+	t.update(l)
+	return l
+}
+
+// balance 更新节点信息，左右子树高度差超过 1 时进行旋转
+func (t *BST[K, V]) balance(n *TreeNode[BSTEntry[K, V]]) *TreeNode[BSTEntry[K, V]] {
+	t.update(n)
+	factor := t.height(n.left) - t.height(n.right)
+	if factor > 1 {
+		if t.height(n.left.left) < t.height(n.left.right) {
+			n.SetLeftNode(t.rotateLeft(n.left))
+		}
+		return t.rotateRight(n)
+	}
+	if factor < -1 {
+		if t.height(n.right.right) < t.height(n.right.left) {
+			n.SetRightNode(t.rotateRight(n.right))
+		}
+		return t.rotateLeft(n)
+	}
+	return n
+}
+
+func (t *BST[K, V]) insert(n *TreeNode[BSTEntry[K, V]], k K, v V) *TreeNode[BSTEntry[K, V]] {
+	if n == nil {
+		return NewTree(BSTEntry[K, V]{Key: k, Value: v, height: 1, size: 1})
+	}
+	switch {
+	case k < n.val.Key:
+		n.SetLeftNode(t.insert(n.left, k, v))
+	case k > n.val.Key:
+		n.SetRightNode(t.insert(n.right, k, v))
+	default:
+		n.val.Value = v
+		return n
+	}
+	return t.balance(n)
+}
+
+func (t *BST[K, V]) delete(n *TreeNode[BSTEntry[K, V]], k K) (*TreeNode[BSTEntry[K, V]], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var ok bool
+	switch {
+	case k < n.val.Key:
+		n.left, ok = t.delete(n.left, k)
+	case k > n.val.Key:
+		n.right, ok = t.delete(n.right, k)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		// 用右子树的最小节点替换当前节点
+		m := t.min(n.right)
+		n.val.Key, n.val.Value = m.val.Key, m.val.Value
+		n.SetRightNode(t.deleteMin(n.right))
+		ok = true
+	}
+	if !ok {
+		return n, false
+	}
+	return t.balance(n), true
+}
+
+func (t *BST[K, V]) deleteMin(n *TreeNode[BSTEntry[K, V]]) *TreeNode[BSTEntry[K, V]] {
+	if n.left == nil {
+		return n.right
+	}
+	n.SetLeftNode(t.deleteMin(n.left))
+	return t.balance(n)
+}
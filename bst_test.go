@@ -0,0 +1,98 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestNewBST(t *testing.T) {
+	tree := util.NewBST[int, string]()
+	assert.True(t, tree.Empty())
+	assert.Equal(t, 0, tree.Len())
+	assert.Nil(t, tree.Min())
+	assert.Nil(t, tree.Max())
+	assert.Nil(t, tree.Get(1))
+	assert.False(t, tree.Delete(1))
+
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9} {
+		tree.Insert(k, string(rune('a'+k)))
+	}
+	assert.Equal(t, 7, tree.Len())
+	assert.Equal(t, []int{1, 3, 4, 5, 7, 8, 9}, tree.Keys())
+	assert.Equal(t, "f", *tree.Get(5))
+	assert.True(t, tree.Has(9))
+	assert.False(t, tree.Has(6))
+
+	tree.Insert(5, "five")
+	assert.Equal(t, 7, tree.Len())
+	assert.Equal(t, "five", *tree.Get(5))
+
+	assert.Equal(t, 1, *tree.Min())
+	assert.Equal(t, 9, *tree.Max())
+
+	assert.Equal(t, 5, *tree.Floor(6))
+	assert.Equal(t, 5, *tree.Floor(5))
+	assert.Nil(t, tree.Floor(0))
+	assert.Equal(t, 7, *tree.Ceiling(6))
+	assert.Equal(t, 7, *tree.Ceiling(7))
+	assert.Nil(t, tree.Ceiling(10))
+
+	assert.Equal(t, 0, tree.Rank(1))
+	assert.Equal(t, 3, tree.Rank(5))
+	assert.Equal(t, 4, tree.Rank(6))
+	assert.Equal(t, 7, tree.Rank(100))
+	assert.Equal(t, 1, *tree.Select(0))
+	assert.Equal(t, 5, *tree.Select(3))
+	assert.Equal(t, 9, *tree.Select(6))
+	assert.Nil(t, tree.Select(7))
+	assert.Nil(t, tree.Select(-1))
+
+	assert.True(t, tree.Delete(5))
+	assert.False(t, tree.Delete(5))
+	assert.Equal(t, []int{1, 3, 4, 7, 8, 9}, tree.Keys())
+	assert.NotNil(t, tree.Root())
+
+	tree.Clear()
+	assert.True(t, tree.Empty())
+	assert.Equal(t, []int{}, tree.Keys())
+}
+
+func TestBST_Balance(t *testing.T) {
+	tree := util.NewBST[int, int]()
+	m := map[int]int{}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		k := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			_, ok := m[k]
+			assert.Equal(t, ok, tree.Delete(k))
+			delete(m, k)
+		} else {
+			tree.Insert(k, i)
+			m[k] = i
+		}
+	}
+	assert.Equal(t, len(m), tree.Len())
+	for k, v := range m {
+		assert.Equal(t, v, *tree.Get(k))
+	}
+	keys := tree.Keys()
+	for i, k := range keys {
+		assert.Equal(t, i, tree.Rank(k))
+		assert.Equal(t, k, *tree.Select(i))
+	}
+	assert.Equal(t, util.Sort(append([]int{}, keys...)), keys)
+	// AVL 树的高度不超过 1.44 * log2(n + 2)
+	assert.LessOrEqual(t, float64(tree.Height()), 1.44*math.Log2(float64(tree.Len()+2)))
+
+	// 顺序插入是普通二叉搜索树的最坏情况
+	sorted := util.NewBST[int, int]()
+	for i := 0; i < 1024; i++ {
+		sorted.Insert(i, i)
+	}
+	assert.LessOrEqual(t, sorted.Height(), 11)
+}
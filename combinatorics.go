@@ -0,0 +1,235 @@
+package util
+
+// 排列组合相关的方法都返回惰性的 Iter，只有在迭代时才会生成下一个结果，
+// 结果数量很大时也不会占用过多内存，可以配合 Take、Each 等方法使用。
+// 每次返回的切片都是新分配的，可以直接保存。
+// Distinct 前缀的方法会把相等的元素视为同一个，只返回不重复的结果，
+// 结果中的元素按照第一次出现的顺序排列，相等的元素会排在一起。
+
+// Permutations 全排列，按照元素下标的字典序返回
+// 数组为空时返回一个空排列
+func Permutations[T any](arr []T) *Iter[[]T] {
+	return permutations(arr, seqIDs(len(arr)))
+}
+
+// DistinctPermutations 同 Permutations，但是有重复元素时只返回不重复的排列
+func DistinctPermutations[T comparable](arr []T) *Iter[[]T] {
+	return permutations(arr, distinctIDs(arr))
+}
+
+// Combinations 从数组中选出 k 个元素的所有组合，组合内保持元素原来的顺序
+// k 为 0 时返回一个空组合，k 小于 0 或者大于数组长度时没有结果
+func Combinations[T any](arr []T, k int) *Iter[[]T] {
+	return combinations(arr, seqIDs(len(arr)), k)
+}
+
+// DistinctCombinations 同 Combinations，但是有重复元素时只返回不重复的组合
+func DistinctCombinations[T comparable](arr []T, k int) *Iter[[]T] {
+	return combinations(arr, distinctIDs(arr), k)
+}
+
+// CombinationsWithReplacement 可重复选取的组合，每个元素可以被选取多次
+func CombinationsWithReplacement[T any](arr []T, k int) *Iter[[]T] {
+	return combinationsWithReplacement(arr, seqIDs(len(arr)), k)
+}
+
+// DistinctCombinationsWithReplacement 同 CombinationsWithReplacement，但是有重复元素时只返回不重复的组合
+func DistinctCombinationsWithReplacement[T comparable](arr []T, k int) *Iter[[]T] {
+	return combinationsWithReplacement(arr, Unique(distinctIDs(arr)), k)
+}
+
+// PowerSet 幂集，按照元素个数从少到多返回所有的子集，包含空集
+func PowerSet[T any](arr []T) *Iter[[]T] {
+	return powerSet(arr, seqIDs(len(arr)))
+}
+
+// DistinctPowerSet 同 PowerSet，但是有重复元素时只返回不重复的子集
+func DistinctPowerSet[T comparable](arr []T) *Iter[[]T] {
+	return powerSet(arr, distinctIDs(arr))
+}
+
+// CartesianProduct 笛卡尔积，按照最后一个数组变化最快的顺序返回
+// 没有传入数组时返回一个空组合，任意一个数组为空时没有结果
+func CartesianProduct[T any](arrs ...[]T) *Iter[[]T] {
+	for _, arr := range arrs {
+		if len(arr) == 0 {
+			return emptyIter[[]T]()
+		}
+	}
+	idx := make([]int, len(arrs))
+	done := false
+	return IterFromFunc(func() ([]T, bool) {
+		if done {
+			return nil, false
+		}
+		ret := make([]T, len(arrs))
+		for i, arr := range arrs {
+			ret[i] = arr[idx[i]]
+		}
+		// 类似里程表进位
+		i := len(arrs) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(arrs[i]) {
+				break
+			}
+			idx[i] = 0
+		}
+		done = i < 0
+		return ret, true
+	})
+}
+
+// DistinctCartesianProduct 同 CartesianProduct，但是会先对每个数组去重
+func DistinctCartesianProduct[T comparable](arrs ...[]T) *Iter[[]T] {
+	return CartesianProduct(Map(arrs, Unique[T])...)
+}
+
+// seqIDs 每个元素都视为不同的元素
+func seqIDs(n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i
+	}
+	return ids
+}
+
+// distinctIDs 使用元素第一次出现的下标作为 id，相等的元素 id 相同，返回排好序的 id
+func distinctIDs[T comparable](arr []T) []int {
+	first := map[T]int{}
+	ids := make([]int, len(arr))
+	for i, v := range arr {
+		if _, ok := first[v]; !ok {
+			first[v] = i
+		}
+		ids[i] = first[v]
+	}
+	return Sort(ids)
+}
+
+// pick 按照 id 取出对应的元素
+func pick[T any](arr []T, ids []int) []T {
+	ret := make([]T, len(ids))
+	for i, id := range ids {
+		ret[i] = arr[id]
+	}
+	return ret
+}
+
+func emptyIter[T any]() *Iter[T] {
+	return IterFromFunc(func() (T, bool) {
+		var zero T
+		return zero, false
+	})
+}
+
+// permutations ids 需要从小到大排好序，相同的 id 不会产生重复的排列
+func permutations[T any](arr []T, ids []int) *Iter[[]T] {
+	done := false
+	return IterFromFunc(func() ([]T, bool) {
+		if done {
+			return nil, false
+		}
+		ret := pick(arr, ids)
+		done = !nextPermutation(ids)
+		return ret, true
+	})
+}
+
+// nextPermutation 原地变为字典序的下一个排列，已经是最后一个排列时返回 false
+func nextPermutation(ids []int) bool {
+	i := len(ids) - 2
+	for i >= 0 && ids[i] >= ids[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(ids) - 1
+	for ids[j] <= ids[i] {
+		j--
+	}
+	ids[i], ids[j] = ids[j], ids[i]
+	Reverse(ids[i+1:])
+	return true
+}
+
+// combinations ids 需要从小到大排好序，pos 记录每个选中元素在 ids 中的位置
+func combinations[T any](arr []T, ids []int, k int) *Iter[[]T] {
+	n := len(ids)
+	if k < 0 || k > n {
+		return emptyIter[[]T]()
+	}
+	pos := seqIDs(k)
+	done := false
+	return IterFromFunc(func() ([]T, bool) {
+		if done {
+			return nil, false
+		}
+		ret := make([]T, k)
+		for i, p := range pos {
+			ret[i] = arr[ids[p]]
+		}
+		// 从右往左找到可以换成下一个更大 id 的位置，后面的位置依次取紧跟着的元素
+		done = true
+		for i := k - 1; i >= 0; i-- {
+			q := UpperBound(ids, ids[pos[i]])
+			if q+k-i <= n {
+				for j := i; j < k; j++ {
+					pos[j] = q + j - i
+				}
+				done = false
+				break
+			}
+		}
+		return ret, true
+	})
+}
+
+// combinationsWithReplacement ids 为可以选取的不重复的 id
+func combinationsWithReplacement[T any](arr []T, ids []int, k int) *Iter[[]T] {
+	m := len(ids)
+	if k < 0 || (m == 0 && k > 0) {
+		return emptyIter[[]T]()
+	}
+	idx := make([]int, k)
+	done := false
+	return IterFromFunc(func() ([]T, bool) {
+		if done {
+			return nil, false
+		}
+		ret := make([]T, k)
+		for i, x := range idx {
+			ret[i] = arr[ids[x]]
+		}
+		done = true
+		for i := k - 1; i >= 0; i-- {
+			if idx[i] < m-1 {
+				idx[i]++
+				for j := i + 1; j < k; j++ {
+					idx[j] = idx[i]
+				}
+				done = false
+				break
+			}
+		}
+		return ret, true
+	})
+}
+
+func powerSet[T any](arr []T, ids []int) *Iter[[]T] {
+	k := 0
+	cur := combinations(arr, ids, k)
+	return IterFromFunc(func() ([]T, bool) {
+		for {
+			if v, ok := cur.Next(); ok {
+				return v, true
+			}
+			if k >= len(ids) {
+				return nil, false
+			}
+			k++
+			cur = combinations(arr, ids, k)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestPermutations(t *testing.T) {
+	assert.Equal(t, [][]int{
+		{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1},
+	}, util.Permutations([]int{1, 2, 3}).Collect())
+	assert.Equal(t, [][]int{{}}, util.Permutations([]int{}).Collect())
+	assert.Equal(t, 6, util.Permutations([]int{1, 1, 2}).Count())
+
+	assert.Equal(t, [][]string{
+		{"a", "a", "b"}, {"a", "b", "a"}, {"b", "a", "a"},
+	}, util.DistinctPermutations([]string{"a", "b", "a"}).Collect())
+
+	// 惰性生成，10! 个排列只取前两个
+	arr := rangeInts(10)
+	assert.Equal(t, [][]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{0, 1, 2, 3, 4, 5, 6, 7, 9, 8},
+	}, util.Permutations(arr).Take(2).Collect())
+}
+
+func TestCombinations(t *testing.T) {
+	assert.Equal(t, [][]string{
+		{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"},
+	}, util.Combinations([]string{"a", "b", "c", "d"}, 2).Collect())
+	assert.Equal(t, [][]int{{}}, util.Combinations([]int{1, 2}, 0).Collect())
+	assert.Equal(t, [][]int{}, util.Combinations([]int{1, 2}, 3).Collect())
+	assert.Equal(t, [][]int{}, util.Combinations([]int{1, 2}, -1).Collect())
+	assert.Equal(t, 3, util.Combinations([]int{1, 1, 2}, 2).Count())
+
+	assert.Equal(t, [][]int{}, util.DistinctCombinations([]int{}, 2).Collect())
+	assert.Equal(t, [][]int{{1, 1}, {1, 2}, {2, 2}}, util.DistinctCombinations([]int{1, 2, 1, 2}, 2).Collect())
+	assert.Equal(t, [][]int{{1, 1, 2}, {1, 2, 2}}, util.DistinctCombinations([]int{1, 2, 1, 2}, 3).Collect())
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	assert.Equal(t, [][]string{
+		{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"},
+	}, util.CombinationsWithReplacement([]string{"a", "b", "c"}, 2).Collect())
+	assert.Equal(t, [][]int{{}}, util.CombinationsWithReplacement([]int{}, 0).Collect())
+	assert.Equal(t, [][]int{}, util.CombinationsWithReplacement([]int{}, 1).Collect())
+	assert.Equal(t, 3, util.CombinationsWithReplacement([]int{1, 1}, 2).Count())
+	assert.Equal(t, [][]int{{1, 1}}, util.DistinctCombinationsWithReplacement([]int{1, 1}, 2).Collect())
+}
+
+func TestPowerSet(t *testing.T) {
+	assert.Equal(t, [][]int{
+		{}, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3},
+	}, util.PowerSet([]int{1, 2, 3}).Collect())
+	assert.Equal(t, [][]int{{}}, util.PowerSet([]int{}).Collect())
+	assert.Equal(t, 8, util.PowerSet([]int{1, 1, 2}).Count())
+	assert.Equal(t, [][]int{
+		{}, {1}, {2}, {1, 1}, {1, 2}, {1, 1, 2},
+	}, util.DistinctPowerSet([]int{1, 2, 1}).Collect())
+}
+
+func TestCartesianProduct(t *testing.T) {
+	assert.Equal(t, [][]string{
+		{"a", "x"}, {"a", "y"}, {"b", "x"}, {"b", "y"},
+	}, util.CartesianProduct([]string{"a", "b"}, []string{"x", "y"}).Collect())
+	assert.Equal(t, [][]int{{}}, util.CartesianProduct[int]().Collect())
+	assert.Equal(t, [][]int{}, util.CartesianProduct([]int{1}, []int{}).Collect())
+	assert.Equal(t, [][]int{{1, 3}, {2, 3}}, util.DistinctCartesianProduct([]int{1, 2, 1}, []int{3, 3}).Collect())
+
+	var got [][]int
+	util.CartesianProduct(rangeInts(100), rangeInts(100), rangeInts(100)).Each(func(v []int) bool {
+		got = append(got, v)
+		return len(got) < 3
+	})
+	assert.Equal(t, [][]int{{0, 0, 0}, {0, 0, 1}, {0, 0, 2}}, got)
+}
@@ -0,0 +1,142 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrQueueClosed 队列已关闭且没有剩余元素
+var ErrQueueClosed = errors.New("util: queue closed")
+
+// ConcurrentQueue 并发安全的队列，在 Queue 的基础上增加了阻塞的 PopWait
+type ConcurrentQueue[T any] struct {
+	mu     sync.Mutex
+	queue  *Queue[T]
+	closed bool
+	// waiters 正在 PopWait 中等待的协程数
+	waiters int
+	// notify 有新元素或者队列关闭时 close，用于唤醒所有等待的协程
+	notify chan struct{}
+}
+
+func NewConcurrentQueue[T any]() *ConcurrentQueue[T] {
+	return &ConcurrentQueue[T]{
+		queue:  NewQueue[T](),
+		notify: make(chan struct{}),
+	}
+}
+
+// Push 向队列末尾添加元素
+// 与向已关闭的 channel 发送数据一样，向已关闭的队列添加元素会 panic
+func (q *ConcurrentQueue[T]) Push(v T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		panic("util: push on closed queue")
+	}
+	q.queue.Push(v)
+	q.wakeLocked()
+}
+
+// Pop 弹出队列头部的元素，队列为空时立即返回 nil
+func (q *ConcurrentQueue[T]) Pop() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Pop()
+}
+
+// PopWait 弹出队列头部的元素，队列为空时阻塞等待
+// 队列关闭且没有剩余元素时返回 ErrQueueClosed，ctx 取消时返回 ctx.Err()
+func (q *ConcurrentQueue[T]) PopWait(ctx context.Context) (*T, error) {
+	for {
+		q.mu.Lock()
+		if v := q.queue.Pop(); v != nil {
+			q.mu.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return nil, ErrQueueClosed
+		}
+		q.waiters++
+		notify := q.notify
+		q.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			q.mu.Lock()
+			// notify 已被替换说明唤醒时已经重置了 waiters
+			if notify == q.notify {
+				q.waiters--
+			}
+			q.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close 关闭队列并唤醒所有等待中的 PopWait
+// 关闭后仍然可以取出剩余的元素，重复关闭不会有影响
+func (q *ConcurrentQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.wakeLocked()
+}
+
+// Closed 队列是否已经关闭
+func (q *ConcurrentQueue[T]) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+func (q *ConcurrentQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Clear()
+}
+
+func (q *ConcurrentQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Len()
+}
+
+// Head 返回队列头部元素的副本，队列为空时返回 nil
+func (q *ConcurrentQueue[T]) Head() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return copyPtr(q.queue.Head())
+}
+
+// End 返回队列末尾元素的副本，队列为空时返回 nil
+func (q *ConcurrentQueue[T]) End() *T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return copyPtr(q.queue.End())
+}
+
+// wakeLocked 唤醒所有等待的协程，调用时需要持有锁
+func (q *ConcurrentQueue[T]) wakeLocked() {
+	if q.waiters == 0 {
+		return
+	}
+	close(q.notify)
+	q.notify = make(chan struct{})
+	q.waiters = 0
+}
+
+// copyPtr 复制指针指向的值，避免返回的指针与内部数据共享
+func copyPtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
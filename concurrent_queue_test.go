@@ -0,0 +1,125 @@
+package util_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestNewConcurrentQueue(t *testing.T) {
+	q := util.NewConcurrentQueue[int]()
+	assert.Equal(t, 0, q.Len())
+	assert.Nil(t, q.Head())
+	assert.Nil(t, q.End())
+	assert.Nil(t, q.Pop())
+
+	q.Push(1)
+	q.Push(2)
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, 1, *q.Head())
+	assert.Equal(t, 2, *q.End())
+	assert.Equal(t, 1, *q.Pop())
+
+	v, err := q.PopWait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *v)
+
+	q.Push(3)
+	q.Clear()
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestConcurrentQueue_PopWait(t *testing.T) {
+	q := util.NewConcurrentQueue[int]()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	v, err := q.PopWait(ctx)
+	assert.Nil(t, v)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	got := make(chan int)
+	go func() {
+		v, err := q.PopWait(context.Background())
+		assert.NoError(t, err)
+		got <- *v
+	}()
+	time.Sleep(5 * time.Millisecond)
+	q.Push(1)
+	assert.Equal(t, 1, <-got)
+}
+
+func TestConcurrentQueue_Close(t *testing.T) {
+	q := util.NewConcurrentQueue[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := q.PopWait(context.Background())
+			assert.ErrorIs(t, err, util.ErrQueueClosed)
+		}()
+	}
+	time.Sleep(5 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+	assert.True(t, q.Closed())
+	assert.Panics(t, func() { q.Push(1) })
+
+	// 关闭后仍然可以取出剩余的元素
+	q2 := util.NewConcurrentQueue[int]()
+	q2.Push(1)
+	q2.Close()
+	v, err := q2.PopWait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *v)
+	_, err = q2.PopWait(context.Background())
+	assert.ErrorIs(t, err, util.ErrQueueClosed)
+}
+
+func TestConcurrentQueue_Race(t *testing.T) {
+	const producers, consumers, perProducer = 8, 8, 1000
+	q := util.NewConcurrentQueue[int]()
+
+	var consumed sync.WaitGroup
+	sums := make([]int, consumers)
+	for i := 0; i < consumers; i++ {
+		consumed.Add(1)
+		go func(i int) {
+			defer consumed.Done()
+			for {
+				v, err := q.PopWait(context.Background())
+				if err != nil {
+					return
+				}
+				sums[i] += *v
+				_ = q.Len()
+				_ = q.Head()
+			}
+		}(i)
+	}
+
+	var produced sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		produced.Add(1)
+		go func() {
+			defer produced.Done()
+			for j := 1; j <= perProducer; j++ {
+				q.Push(j)
+			}
+		}()
+	}
+	produced.Wait()
+	q.Close()
+	consumed.Wait()
+
+	var total int
+	for _, s := range sums {
+		total += s
+	}
+	assert.Equal(t, producers*perProducer*(perProducer+1)/2, total)
+}
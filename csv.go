@@ -0,0 +1,327 @@
+package util
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVOptions CSV 读写的配置，零值即为默认配置
+type CSVOptions struct {
+	// Comma 分隔符，默认为 ','
+	Comma rune
+	// NoHeader 为 true 时不写入表头，读取时按照字段顺序对应列
+	NoHeader bool
+	// TimeLayout time.Time 字段的格式，默认为 time.RFC3339，可以被标签中的 layout 覆盖
+	TimeLayout string
+	// FloatPrec 浮点数保留的小数位数，默认为 -1(最少的位数)，可以被标签中的 prec 覆盖
+	FloatPrec *int
+}
+
+// CSVError CSV 读取时某一行的错误
+type CSVError struct {
+	// Line 出错的行号，从 1 开始，包含表头
+	Line int
+	// Column 出错的列名，整行出错时为空
+	Column string
+	Err    error
+}
+
+func (e *CSVError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("util: csv line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("util: csv line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *CSVError) Unwrap() error {
+	return e.Err
+}
+
+// csvField 结构体字段与 CSV 列的对应关系
+// 标签格式为 `csv:"name,layout=2006-01-02,prec=2"`，`csv:"-"` 表示忽略该字段
+type csvField struct {
+	name   string
+	index  int
+	layout string
+	prec   int
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func csvOptions(opts []CSVOptions) CSVOptions {
+	var o CSVOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Comma == 0 {
+		o.Comma = ','
+	}
+	if o.TimeLayout == "" {
+		o.TimeLayout = time.RFC3339
+	}
+	return o
+}
+
+func csvFields(t reflect.Type, o CSVOptions) ([]csvField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("util: csv requires struct elements, got %s", t)
+	}
+	prec := -1
+	if o.FloatPrec != nil {
+		prec = *o.FloatPrec
+	}
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		f := csvField{name: parts[0], index: i, layout: o.TimeLayout, prec: prec}
+		if f.name == "" {
+			f.name = sf.Name
+		}
+		for _, p := range parts[1:] {
+			k, v, _ := strings.Cut(p, "=")
+			switch k {
+			case "layout":
+				f.layout = v
+			case "prec":
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("util: csv field %s: invalid prec %q", sf.Name, v)
+				}
+				f.prec = n
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// WriteCSV 将结构体数组写入 w，结构体字段通过 csv 标签与列对应
+func WriteCSV[T any](w io.Writer, arr []T, opts ...CSVOptions) error {
+	o := csvOptions(opts)
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem(), o)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = o.Comma
+	if !o.NoHeader {
+		if err := cw.Write(Map(fields, func(f csvField) string {
+			return f.name
+		})); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(fields))
+	for i, v := range arr {
+		rv := reflect.ValueOf(v)
+		for j, f := range fields {
+			s, err := formatCSVValue(rv.Field(f.index), f)
+			if err != nil {
+				return fmt.Errorf("util: csv element %d, column %q: %w", i, f.name, err)
+			}
+			record[j] = s
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV 从 r 中读取结构体数组，以集合返回
+// 解析失败或者列数与表头不一致的行会被跳过，所有行的错误以 *CSVError 合并后返回，可以通过 errors.As 获取行号
+// 表头中不存在于结构体的列会被忽略
+func ReadCSV[T comparable](r io.Reader, opts ...CSVOptions) (*Slice[T], error) {
+	o := csvOptions(opts)
+	fields, err := csvFields(reflect.TypeOf((*T)(nil)).Elem(), o)
+	if err != nil {
+		return nil, err
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = o.Comma
+	cr.FieldsPerRecord = -1
+
+	// columns[i] 为第 i 列对应的字段，nil 表示忽略该列
+	columns := make([]*csvField, len(fields))
+	for i := range fields {
+		columns[i] = &fields[i]
+	}
+	if !o.NoHeader {
+		header, err := cr.Read()
+		if err == io.EOF {
+			return NewSlice([]T{}), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		byName := map[string]*csvField{}
+		for i := range fields {
+			byName[fields[i].name] = &fields[i]
+		}
+		columns = Map(header, func(name string) *csvField {
+			return byName[strings.TrimSpace(name)]
+		})
+	}
+
+	ret := []T{}
+	var errs []error
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, &CSVError{Line: parseErr.Line, Err: parseErr.Err})
+				continue
+			}
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if len(record) != len(columns) {
+			errs = append(errs, &CSVError{Line: line, Err: fmt.Errorf("expected %d fields, got %d", len(columns), len(record))})
+			continue
+		}
+		var v T
+		rv := reflect.ValueOf(&v).Elem()
+		ok := true
+		for i, s := range record {
+			if i >= len(columns) || columns[i] == nil {
+				continue
+			}
+			f := columns[i]
+			if err := parseCSVValue(rv.Field(f.index), s, *f); err != nil {
+				errs = append(errs, &CSVError{Line: line, Column: f.name, Err: err})
+				ok = false
+			}
+		}
+		if ok {
+			ret = append(ret, v)
+		}
+	}
+	return NewSlice(ret), JoinErrors(errs...)
+}
+
+// WriteCSV 将集合中的结构体写入 w，见 WriteCSV
+func (s *Slice[T]) WriteCSV(w io.Writer, opts ...CSVOptions) error {
+	return WriteCSV(w, s.Slice(), opts...)
+}
+
+func formatCSVValue(v reflect.Value, f csvField) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(f.layout), nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', f.prec, v.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func parseCSVValue(v reflect.Value, s string, f csvField) error {
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		if s == "" {
+			v.Set(reflect.Zero(timeType))
+			return nil
+		}
+		t, err := time.Parse(f.layout, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package util_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+
+	util "github.com/zhan3333/goutil"
+)
+
+type csvRecord struct {
+	ID       int       `csv:"id"`
+	Name     string    `csv:"name"`
+	Price    float64   `csv:"price,prec=2"`
+	Active   bool      `csv:"active"`
+	Created  time.Time `csv:"created,layout=2006-01-02"`
+	Note     *string   `csv:"note"`
+	Internal string    `csv:"-"`
+	Score    uint8
+}
+
+func csvRecords() []csvRecord {
+	note := "a, \"quoted\" note"
+	return []csvRecord{
+		{ID: 1, Name: "apple", Price: 1.5, Active: true, Created: time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), Note: &note, Internal: "x", Score: 9},
+		{ID: 2, Name: "pear", Price: 20, Score: 1},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, util.NewSlice(csvRecords()).WriteCSV(&buf))
+	assert.Equal(t, `id,name,price,active,created,note,Score
+1,apple,1.50,true,2022-05-01,"a, ""quoted"" note",9
+2,pear,20.00,false,,,1
+`, buf.String())
+
+	buf.Reset()
+	prec := -1
+	assert.NoError(t, util.WriteCSV(&buf, csvRecords()[1:], util.CSVOptions{Comma: ';', NoHeader: true, FloatPrec: &prec}))
+	assert.Equal(t, "2;pear;20.00;false;;;1\n", buf.String())
+
+	// 与链式调用组合
+	buf.Reset()
+	assert.NoError(t, util.NewSlice(csvRecords()).Filter(func(r csvRecord) bool {
+		return r.Active
+	}).WriteCSV(&buf, util.CSVOptions{NoHeader: true}))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	assert.Error(t, util.WriteCSV(&buf, []int{1}))
+}
+
+func TestReadCSV(t *testing.T) {
+	var buf bytes.Buffer
+	in := util.NewSlice(csvRecords())
+	assert.NoError(t, in.WriteCSV(&buf))
+	out, err := util.ReadCSV[csvRecord](&buf)
+	assert.NoError(t, err)
+	want := csvRecords()
+	want[0].Internal = ""
+	assert.Equal(t, want, out.Slice())
+
+	// 列顺序不同、多余的列、缺少的列
+	out, err = util.ReadCSV[csvRecord](strings.NewReader("name,extra,id\nkiwi,?,3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []csvRecord{{ID: 3, Name: "kiwi"}}, out.Slice())
+
+	out, err = util.ReadCSV[csvRecord](strings.NewReader("4;lime;0.5;true;;;2\n"), util.CSVOptions{Comma: ';', NoHeader: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []csvRecord{{ID: 4, Name: "lime", Price: 0.5, Active: true, Score: 2}}, out.Slice())
+
+	out, err = util.ReadCSV[csvRecord](strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Equal(t, []csvRecord{}, out.Slice())
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	data := "id,name,created\n1,a,2022-01-01\nx,b,2022-01-02\n3,c,bad\n4,d,\n5,e\n6,f,2022-01-06,x\n"
+	out, err := util.ReadCSV[csvRecord](strings.NewReader(data))
+	assert.Equal(t, []int{1, 4}, util.Map(out.Slice(), func(r csvRecord) int {
+		return r.ID
+	}))
+
+	var multi *util.MultiError
+	assert.True(t, errors.As(err, &multi))
+	lines := util.Map(multi.Unwrap(), func(err error) int {
+		var csvErr *util.CSVError
+		assert.True(t, errors.As(err, &csvErr))
+		return csvErr.Line
+	})
+	assert.Equal(t, []int{3, 4, 6, 7}, lines)
+	assert.Contains(t, err.Error(), `util: csv line 3, column "id"`)
+	assert.Contains(t, err.Error(), `util: csv line 6: expected 3 fields, got 2`)
+	assert.Contains(t, err.Error(), `util: csv line 7: expected 3 fields, got 4`)
+
+	_, err = util.ReadCSV[int](strings.NewReader("1\n"))
+	assert.Error(t, err)
+}
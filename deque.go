@@ -0,0 +1,155 @@
+package util
+
+// Deque 双端队列，基于可扩容的环形缓冲区，两端的插入与弹出都是 O(1)
+// 队尾是 Push 的一端，作为 Queue 使用时用 Push/Pop/Head/End，作为 Stack 使用时用 Push/PopTop/Top
+type Deque[T any] struct {
+	items []T
+	head  int
+	len   int
+}
+
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// PushFront 在队头添加元素
+func (d *Deque[T]) PushFront(v T) {
+	d.growIfFull()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = v
+	d.len++
+}
+
+// PushBack 在队尾添加元素
+func (d *Deque[T]) PushBack(v T) {
+	d.growIfFull()
+	d.items[d.index(d.len)] = v
+	d.len++
+}
+
+// PopFront 弹出队头元素，队列为空时返回 nil
+func (d *Deque[T]) PopFront() *T {
+	if d.len == 0 {
+		return nil
+	}
+	v := d.items[d.head]
+	var zero T
+	d.items[d.head] = zero
+	d.head = d.index(1)
+	d.len--
+	return &v
+}
+
+// PopBack 弹出队尾元素，队列为空时返回 nil
+func (d *Deque[T]) PopBack() *T {
+	if d.len == 0 {
+		return nil
+	}
+	i := d.index(d.len - 1)
+	v := d.items[i]
+	var zero T
+	d.items[i] = zero
+	d.len--
+	return &v
+}
+
+// Front 返回队头元素，队列为空时返回 nil
+func (d *Deque[T]) Front() *T {
+	return d.At(0)
+}
+
+// Back 返回队尾元素，队列为空时返回 nil
+func (d *Deque[T]) Back() *T {
+	return d.At(d.len - 1)
+}
+
+// At 返回从队头开始第 i 个元素，下标越界时返回 nil
+func (d *Deque[T]) At(i int) *T {
+	if i < 0 || i >= d.len {
+		return nil
+	}
+	return &d.items[d.index(i)]
+}
+
+func (d *Deque[T]) Len() int {
+	return d.len
+}
+
+func (d *Deque[T]) Empty() bool {
+	return d.len == 0
+}
+
+func (d *Deque[T]) Clear() {
+	d.items = nil
+	d.head = 0
+	d.len = 0
+}
+
+// Push 同 PushBack，与 Queue、Stack 保持一致
+func (d *Deque[T]) Push(v T) {
+	d.PushBack(v)
+}
+
+// Pop 同 PopFront，按照 Queue 的先进先出顺序弹出
+func (d *Deque[T]) Pop() *T {
+	return d.PopFront()
+}
+
+// PopTop 同 PopBack，按照 Stack 的后进先出顺序弹出
+func (d *Deque[T]) PopTop() *T {
+	return d.PopBack()
+}
+
+// Head 同 Front，与 Queue 保持一致
+func (d *Deque[T]) Head() *T {
+	return d.Front()
+}
+
+// End 同 Back，与 Queue 保持一致
+func (d *Deque[T]) End() *T {
+	return d.Back()
+}
+
+// Top 同 Back，与 Stack 保持一致
+func (d *Deque[T]) Top() *T {
+	return d.Back()
+}
+
+// Each 从队头到队尾遍历元素，使用 f 的返回值替换元素
+func (d *Deque[T]) Each(f func(v T) T) {
+	for i := 0; i < d.len; i++ {
+		k := d.index(i)
+		d.items[k] = f(d.items[k])
+	}
+}
+
+// Slice 从队头到队尾返回所有元素
+func (d *Deque[T]) Slice() []T {
+	ret := make([]T, d.len)
+	for i := range ret {
+		ret[i] = d.items[d.index(i)]
+	}
+	return ret
+}
+
+// index 将从队头开始的下标转换为缓冲区中的下标
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) % len(d.items)
+}
+
+func (d *Deque[T]) growIfFull() {
+	if d.len < len(d.items) {
+		return
+	}
+	size := len(d.items) * 2
+	if size == 0 {
+		size = 8
+	}
+	items := make([]T, size)
+	if d.len > 0 {
+		n := copy(items, d.items[d.head:])
+		copy(items[n:], d.items[:d.head])
+	}
+	d.items = items
+	d.head = 0
+}
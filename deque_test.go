@@ -0,0 +1,115 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestNewDeque(t *testing.T) {
+	d := util.NewDeque[int]()
+	assert.True(t, d.Empty())
+	assert.Nil(t, d.Front())
+	assert.Nil(t, d.Back())
+	assert.Nil(t, d.PopFront())
+	assert.Nil(t, d.PopBack())
+	assert.Nil(t, d.At(0))
+
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+	assert.Equal(t, 4, d.Len())
+	assert.Equal(t, []int{0, 1, 2, 3}, d.Slice())
+	assert.Equal(t, 0, *d.Front())
+	assert.Equal(t, 3, *d.Back())
+	assert.Equal(t, 2, *d.At(2))
+	assert.Nil(t, d.At(4))
+	assert.Nil(t, d.At(-1))
+	assert.Equal(t, 0, *d.Head())
+	assert.Equal(t, 3, *d.End())
+	assert.Equal(t, 3, *d.Top())
+
+	assert.Equal(t, 0, *d.PopFront())
+	assert.Equal(t, 3, *d.PopBack())
+	assert.Equal(t, []int{1, 2}, d.Slice())
+
+	d.Each(func(v int) int {
+		return v * 10
+	})
+	assert.Equal(t, []int{10, 20}, d.Slice())
+
+	d.Clear()
+	assert.Equal(t, 0, d.Len())
+	assert.Equal(t, []int{}, d.Slice())
+}
+
+func TestDeque_QueueStack(t *testing.T) {
+	// 作为队列使用，与 Queue 的行为一致
+	q := util.NewDeque[int]()
+	lq := util.NewQueue[int]()
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+		lq.Push(i)
+	}
+	assert.Equal(t, *lq.Head(), *q.Head())
+	assert.Equal(t, *lq.End(), *q.End())
+	for lq.Len() > 0 {
+		assert.Equal(t, *lq.Pop(), *q.Pop())
+	}
+	assert.Nil(t, q.Pop())
+
+	// 作为栈使用，与 Stack 的行为一致
+	s := util.NewDeque[int]()
+	ls := util.NewStack[int]()
+	for i := 0; i < 5; i++ {
+		s.Push(i)
+		ls.Push(i)
+	}
+	for !ls.Empty() {
+		assert.Equal(t, *ls.Top(), *s.Top())
+		assert.Equal(t, *ls.Pop(), *s.PopTop())
+	}
+	assert.Nil(t, s.PopTop())
+}
+
+func TestDeque_Grow(t *testing.T) {
+	d := util.NewDeque[int]()
+	var want []int
+	// 交替从两端插入，触发多次环绕与扩容
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+			want = append(want, i)
+		} else {
+			d.PushFront(i)
+			want = append([]int{i}, want...)
+		}
+	}
+	assert.Equal(t, want, d.Slice())
+	for i := range want {
+		assert.Equal(t, want[i], *d.At(i))
+	}
+}
+
+func TestDeque_SlidingWindowMax(t *testing.T) {
+	arr := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	k := 3
+	// 单调队列，保存下标，对应的值递减
+	d := util.NewDeque[int]()
+	var ret []int
+	for i, v := range arr {
+		for !d.Empty() && arr[*d.Back()] <= v {
+			d.PopBack()
+		}
+		d.PushBack(i)
+		if *d.Front() <= i-k {
+			d.PopFront()
+		}
+		if i >= k-1 {
+			ret = append(ret, arr[*d.Front()])
+		}
+	}
+	assert.Equal(t, []int{3, 3, 5, 5, 6, 7}, ret)
+}
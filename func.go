@@ -2,7 +2,6 @@ package util
 
 import (
 	"golang.org/x/exp/constraints"
-	"math/rand"
 	"reflect"
 	"sort"
 )
@@ -85,6 +84,36 @@ func Reduce[I, R any](arr []I, f func(R, I) R) R {
 	return ret
 }
 
+// Fold 同 Reduce，但是从传入的初始值 init 开始
+func Fold[I, R any](arr []I, init R, f func(R, I) R) R {
+	ret := init
+	for _, v := range arr {
+		ret = f(ret, v)
+	}
+	return ret
+}
+
+// FoldRight 同 Fold，但是从最后一个元素开始向前遍历
+func FoldRight[I, R any](arr []I, init R, f func(R, I) R) R {
+	ret := init
+	for i := len(arr) - 1; i >= 0; i-- {
+		ret = f(ret, arr[i])
+	}
+	return ret
+}
+
+// Scan 同 Fold，但是返回每一步的结果，结果的长度与 arr 相同，不包含 init
+// 例如用于计算前缀和
+func Scan[I, R any](arr []I, init R, f func(R, I) R) []R {
+	ret := make([]R, len(arr))
+	acc := init
+	for i, v := range arr {
+		acc = f(acc, v)
+		ret[i] = acc
+	}
+	return ret
+}
+
 // Filter 遍历数组，按照传入的方法过滤数组，返回新的数组
 func Filter[T any](arr []T, f func(T) bool) []T {
 	var ret []T
@@ -157,26 +186,16 @@ func CountIf[T any](arr []T, f func(T) bool) int {
 	return sum
 }
 
+// Random 随机返回一个元素的指针，使用 math/rand 的全局随机源
+// 需要可复现的结果时使用 RandomWith
 func Random[T any](arr []T) *T {
-	if len(arr) == 0 {
-		return nil
-	}
-	return &arr[rand.Intn(len(arr))]
+	return RandomWith(nil, arr)
 }
 
-// Shuffle 打乱数组
+// Shuffle 打乱数组，使用 math/rand 的全局随机源
+// 需要可复现的结果时使用 ShuffleWith
 func Shuffle[T any](arr []T) []T {
-	// 洗牌算法
-	if len(arr) == 0 {
-		return arr
-	}
-	lastI := len(arr) - 1
-	for lastI > 0 {
-		randI := rand.Intn(lastI + 1)
-		arr[randI], arr[lastI] = arr[lastI], arr[randI]
-		lastI--
-	}
-	return arr
+	return ShuffleWith(nil, arr)
 }
 
 // Diff 差集, 存在 arr1 中但是不存在于 arr2 中的元素
@@ -249,3 +268,299 @@ func Sort[T constraints.Ordered](arr []T) []T {
 	sort.Sort(data)
 	return data.slice
 }
+
+// SortDesc 从大到小排序
+func SortDesc[T constraints.Ordered](arr []T) []T {
+	return SortBy(arr, func(a, b T) bool {
+		return a > b
+	})
+}
+
+type sortByData[T any] struct {
+	slice []T
+	less  func(a, b T) bool
+}
+
+func (s sortByData[T]) Len() int {
+	return len(s.slice)
+}
+
+func (s sortByData[T]) Less(i, j int) bool {
+	return s.less(s.slice[i], s.slice[j])
+}
+
+func (s sortByData[T]) Swap(i, j int) {
+	s.slice[i], s.slice[j] = s.slice[j], s.slice[i]
+}
+
+// SortBy 使用传入的比较方法排序，less(a, b) 返回 true 时 a 排在 b 前面
+func SortBy[T any](arr []T, less func(a, b T) bool) []T {
+	sort.Sort(sortByData[T]{arr, less})
+	return arr
+}
+
+// SortStableBy 同 SortBy，但是相等的元素会保持原来的顺序
+func SortStableBy[T any](arr []T, less func(a, b T) bool) []T {
+	sort.Stable(sortByData[T]{arr, less})
+	return arr
+}
+
+// SortByKey 按照 key 方法返回的值从小到大排序，常用于按结构体的某个字段排序
+func SortByKey[T any, K constraints.Ordered](arr []T, key func(T) K) []T {
+	return SortBy(arr, keyLess(key, false))
+}
+
+// SortByKeyDesc 按照 key 方法返回的值从大到小排序
+func SortByKeyDesc[T any, K constraints.Ordered](arr []T, key func(T) K) []T {
+	return SortBy(arr, keyLess(key, true))
+}
+
+// SortStableByKey 同 SortByKey，但是 key 相等的元素会保持原来的顺序
+func SortStableByKey[T any, K constraints.Ordered](arr []T, key func(T) K) []T {
+	return SortStableBy(arr, keyLess(key, false))
+}
+
+// SortStableByKeyDesc 同 SortByKeyDesc，但是 key 相等的元素会保持原来的顺序
+func SortStableByKeyDesc[T any, K constraints.Ordered](arr []T, key func(T) K) []T {
+	return SortStableBy(arr, keyLess(key, true))
+}
+
+func keyLess[T any, K constraints.Ordered](key func(T) K, desc bool) func(a, b T) bool {
+	if desc {
+		return func(a, b T) bool {
+			return key(a) > key(b)
+		}
+	}
+	return func(a, b T) bool {
+		return key(a) < key(b)
+	}
+}
+
+// GroupBy 按照 key 方法的返回值分组，每组内保持原来的顺序
+func GroupBy[T any, K comparable](arr []T, key func(T) K) map[K][]T {
+	ret := map[K][]T{}
+	for _, v := range arr {
+		k := key(v)
+		ret[k] = append(ret[k], v)
+	}
+	return ret
+}
+
+// Groups 保持分组顺序的分组结果，分组按照 key 第一次出现的顺序排列
+type Groups[K comparable, T any] struct {
+	keys   []K
+	groups map[K][]T
+}
+
+// Keys 按照第一次出现的顺序返回所有的 key
+func (g *Groups[K, T]) Keys() []K {
+	return g.keys
+}
+
+// Get 返回 key 对应的分组，不存在时返回 nil
+func (g *Groups[K, T]) Get(k K) []T {
+	return g.groups[k]
+}
+
+// Len 返回分组的个数
+func (g *Groups[K, T]) Len() int {
+	return len(g.keys)
+}
+
+// Each 按顺序遍历分组，f 返回 false 时停止遍历
+func (g *Groups[K, T]) Each(f func(k K, vs []T) bool) {
+	for _, k := range g.keys {
+		if !f(k, g.groups[k]) {
+			return
+		}
+	}
+}
+
+// Map 以 map 的形式返回分组结果
+func (g *Groups[K, T]) Map() map[K][]T {
+	return g.groups
+}
+
+// GroupByOrdered 同 GroupBy，但是返回的分组按照 key 第一次出现的顺序排列
+func GroupByOrdered[T any, K comparable](arr []T, key func(T) K) *Groups[K, T] {
+	g := &Groups[K, T]{keys: []K{}, groups: map[K][]T{}}
+	for _, v := range arr {
+		k := key(v)
+		if _, ok := g.groups[k]; !ok {
+			g.keys = append(g.keys, k)
+		}
+		g.groups[k] = append(g.groups[k], v)
+	}
+	return g
+}
+
+// Partition 按照 f 的返回值将数组分成两部分，都保持原来的顺序
+func Partition[T any](arr []T, f func(T) bool) (yes, no []T) {
+	yes, no = []T{}, []T{}
+	for _, v := range arr {
+		if f(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// KeyBy 按照 key 方法的返回值索引数组元素，key 重复时后面的元素会覆盖前面的
+func KeyBy[T any, K comparable](arr []T, key func(T) K) map[K]T {
+	ret := make(map[K]T, len(arr))
+	for _, v := range arr {
+		ret[key(v)] = v
+	}
+	return ret
+}
+
+// CountBy 按照 key 方法的返回值统计元素个数
+func CountBy[T any, K comparable](arr []T, key func(T) K) map[K]int {
+	ret := map[K]int{}
+	for _, v := range arr {
+		ret[key(v)]++
+	}
+	return ret
+}
+
+// Chunk 每 size 个元素分为一组，最后一组可能不足 size 个，size 小于 1 时按 1 处理
+// 返回的每一组都是原数组的视图，不会复制元素
+func Chunk[T any](arr []T, size int) [][]T {
+	if size < 1 {
+		size = 1
+	}
+	ret := make([][]T, 0, (len(arr)+size-1)/size)
+	for i := 0; i < len(arr); i += size {
+		end := i + size
+		if end > len(arr) {
+			end = len(arr)
+		}
+		// 限制容量，避免对分组 append 时覆盖后面的元素
+		ret = append(ret, arr[i:end:end])
+	}
+	return ret
+}
+
+// ChunkBy 将相邻的元素分组，f(prev, cur) 返回 false 时从 cur 开始新的一组
+// 返回的每一组都是原数组的视图，不会复制元素
+func ChunkBy[T any](arr []T, f func(prev, cur T) bool) [][]T {
+	ret := [][]T{}
+	start := 0
+	for i := 1; i <= len(arr); i++ {
+		if i == len(arr) || !f(arr[i-1], arr[i]) {
+			ret = append(ret, arr[start:i:i])
+			start = i
+		}
+	}
+	return ret
+}
+
+// Windows 滑动窗口，每个窗口包含 size 个元素，相邻窗口的起点相差 step 个元素
+// 元素不足 size 个时不会返回窗口，size 与 step 小于 1 时按 1 处理
+// 返回的每个窗口都是原数组的视图，不会复制元素
+func Windows[T any](arr []T, size, step int) [][]T {
+	if size < 1 {
+		size = 1
+	}
+	if step < 1 {
+		step = 1
+	}
+	ret := [][]T{}
+	for i := 0; i+size <= len(arr); i += step {
+		ret = append(ret, arr[i:i+size:i+size])
+	}
+	return ret
+}
+
+// Intersect 交集，同时存在于 arr1 与 arr2 中的元素，按照在 arr1 中第一次出现的顺序去重
+func Intersect[T comparable](arr1, arr2 []T) []T {
+	return IntersectBy(arr1, arr2, identity[T])
+}
+
+// IntersectBy 同 Intersect，使用 key 方法的返回值判断元素是否相同
+func IntersectBy[T any, K comparable](arr1, arr2 []T, key func(T) K) []T {
+	in2 := make(map[K]bool, len(arr2))
+	for _, v := range arr2 {
+		in2[key(v)] = true
+	}
+	ret := []T{}
+	seen := map[K]bool{}
+	for _, v := range arr1 {
+		k := key(v)
+		if in2[k] && !seen[k] {
+			seen[k] = true
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Union 并集，按照第一次出现的顺序去重
+func Union[T comparable](arrs ...[]T) []T {
+	return UnionBy(identity[T], arrs...)
+}
+
+// UnionBy 同 Union，使用 key 方法的返回值判断元素是否相同
+func UnionBy[T any, K comparable](key func(T) K, arrs ...[]T) []T {
+	ret := []T{}
+	seen := map[K]bool{}
+	for _, arr := range arrs {
+		for _, v := range arr {
+			k := key(v)
+			if !seen[k] {
+				seen[k] = true
+				ret = append(ret, v)
+			}
+		}
+	}
+	return ret
+}
+
+// SymmetricDiff 对称差集，只存在于其中一个数组中的元素
+// 先按顺序返回 arr1 中的元素，再返回 arr2 中的元素，结果会去重
+func SymmetricDiff[T comparable](arr1, arr2 []T) []T {
+	return SymmetricDiffBy(arr1, arr2, identity[T])
+}
+
+// SymmetricDiffBy 同 SymmetricDiff，使用 key 方法的返回值判断元素是否相同
+func SymmetricDiffBy[T any, K comparable](arr1, arr2 []T, key func(T) K) []T {
+	in1 := make(map[K]bool, len(arr1))
+	for _, v := range arr1 {
+		in1[key(v)] = true
+	}
+	in2 := make(map[K]bool, len(arr2))
+	for _, v := range arr2 {
+		in2[key(v)] = true
+	}
+	ret := []T{}
+	seen := map[K]bool{}
+	add := func(arr []T, other map[K]bool) {
+		for _, v := range arr {
+			k := key(v)
+			if !other[k] && !seen[k] {
+				seen[k] = true
+				ret = append(ret, v)
+			}
+		}
+	}
+	add(arr1, in2)
+	add(arr2, in1)
+	return ret
+}
+
+// DiffBy 同 Diff，使用 key 方法的返回值判断元素是否相同
+func DiffBy[T any, K comparable](arr1, arr2 []T, key func(T) K) []T {
+	in2 := make(map[K]bool, len(arr2))
+	for _, v := range arr2 {
+		in2[key(v)] = true
+	}
+	return Filter(arr1, func(v T) bool {
+		return !in2[key(v)]
+	})
+}
+
+func identity[T any](v T) T {
+	return v
+}
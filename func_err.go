@@ -0,0 +1,179 @@
+package util
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrMode 出错时的处理方式
+type ErrMode int
+
+const (
+	// StopOnError 遇到第一个错误时停止，返回该错误
+	StopOnError ErrMode = iota
+	// CollectErrors 遇到错误时继续处理剩余的元素，最后返回所有错误合并后的 MultiError
+	CollectErrors
+)
+
+// MultiError 多个错误的组合
+type MultiError struct {
+	errs []error
+}
+
+// JoinErrors 合并错误，忽略 nil，全部为 nil 时返回 nil
+func JoinErrors(errs ...error) error {
+	errs = Filter(errs, func(err error) bool {
+		return err != nil
+	})
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{errs: errs}
+}
+
+// Error 每行一个错误
+func (e *MultiError) Error() string {
+	return strings.Join(Map(e.errs, error.Error), "\n")
+}
+
+// Unwrap 返回所有的错误
+func (e *MultiError) Unwrap() []error {
+	return e.errs
+}
+
+// Is 任意一个错误满足 errors.Is 时返回 true
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As 找到第一个满足 errors.As 的错误
+func (e *MultiError) As(target any) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// errCollector 根据 ErrMode 收集错误
+type errCollector struct {
+	mode ErrMode
+	errs []error
+}
+
+func newErrCollector(modes []ErrMode) *errCollector {
+	c := &errCollector{}
+	if len(modes) > 0 {
+		c.mode = modes[0]
+	}
+	return c
+}
+
+// add 记录错误，返回是否需要停止
+func (c *errCollector) add(err error) bool {
+	c.errs = append(c.errs, err)
+	return c.mode == StopOnError
+}
+
+func (c *errCollector) err() error {
+	if c.mode == StopOnError && len(c.errs) > 0 {
+		return c.errs[0]
+	}
+	return JoinErrors(c.errs...)
+}
+
+// MapErr 同 Map，f 可以返回错误
+// 默认遇到第一个错误时停止，传入 CollectErrors 时会处理所有元素并合并错误
+// 出现错误时返回 nil 与错误
+func MapErr[T, U any](arr []T, f func(T) (U, error), mode ...ErrMode) ([]U, error) {
+	ret := make([]U, 0, len(arr))
+	c := newErrCollector(mode)
+	for _, v := range arr {
+		u, err := f(v)
+		if err != nil {
+			if c.add(err) {
+				break
+			}
+			continue
+		}
+		ret = append(ret, u)
+	}
+	if err := c.err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// TryMap 同 MapErr，但是总会处理所有元素，返回成功处理的结果与合并后的错误
+func TryMap[T, U any](arr []T, f func(T) (U, error)) ([]U, error) {
+	ret := []U{}
+	c := newErrCollector([]ErrMode{CollectErrors})
+	for _, v := range arr {
+		u, err := f(v)
+		if err != nil {
+			c.add(err)
+			continue
+		}
+		ret = append(ret, u)
+	}
+	return ret, c.err()
+}
+
+// FilterErr 同 Filter，f 可以返回错误，出错的元素不会出现在结果中
+// 出现错误时返回 nil 与错误
+func FilterErr[T any](arr []T, f func(T) (bool, error), mode ...ErrMode) ([]T, error) {
+	ret := []T{}
+	c := newErrCollector(mode)
+	for _, v := range arr {
+		ok, err := f(v)
+		if err != nil {
+			if c.add(err) {
+				break
+			}
+			continue
+		}
+		if ok {
+			ret = append(ret, v)
+		}
+	}
+	if err := c.err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ReduceErr 同 Reduce，f 可以返回错误
+// 使用 CollectErrors 时出错的元素会被跳过，继续使用之前的结果归约
+// 出现错误时返回已经归约的结果与错误
+func ReduceErr[I, R any](arr []I, f func(R, I) (R, error), mode ...ErrMode) (R, error) {
+	var ret R
+	c := newErrCollector(mode)
+	for _, v := range arr {
+		r, err := f(ret, v)
+		if err != nil {
+			if c.add(err) {
+				break
+			}
+			continue
+		}
+		ret = r
+	}
+	return ret, c.err()
+}
+
+// EachErr 遍历数组，f 可以返回错误
+func EachErr[T any](arr []T, f func(T) error, mode ...ErrMode) error {
+	c := newErrCollector(mode)
+	for _, v := range arr {
+		if err := f(v); err != nil && c.add(err) {
+			break
+		}
+	}
+	return c.err()
+}
@@ -0,0 +1,114 @@
+package util_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestJoinErrors(t *testing.T) {
+	assert.Nil(t, util.JoinErrors())
+	assert.Nil(t, util.JoinErrors(nil, nil))
+
+	errA, errB := errors.New("a"), errors.New("b")
+	err := util.JoinErrors(errA, nil, fmt.Errorf("wrap: %w", errB))
+	assert.EqualError(t, err, "a\nwrap: b")
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+	assert.NotErrorIs(t, err, errors.New("a"))
+
+	var numErr *strconv.NumError
+	_, parseErr := strconv.Atoi("x")
+	assert.True(t, errors.As(util.JoinErrors(errA, parseErr), &numErr))
+}
+
+func TestMapErr(t *testing.T) {
+	got, err := util.MapErr([]string{"1", "2", "3"}, strconv.Atoi)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+
+	var calls int
+	atoi := func(v string) (int, error) {
+		calls++
+		return strconv.Atoi(v)
+	}
+	got, err = util.MapErr([]string{"1", "a", "b", "4"}, atoi)
+	assert.Nil(t, got)
+	assert.EqualError(t, err, `strconv.Atoi: parsing "a": invalid syntax`)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	got, err = util.MapErr([]string{"1", "a", "b", "4"}, atoi, util.CollectErrors)
+	assert.Nil(t, got)
+	assert.Len(t, err.(*util.MultiError).Unwrap(), 2)
+	assert.Equal(t, 4, calls)
+
+	got, err = util.MapErr([]string{}, strconv.Atoi)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, got)
+}
+
+func TestTryMap(t *testing.T) {
+	got, err := util.TryMap([]string{"1", "a", "3", "b"}, strconv.Atoi)
+	assert.Equal(t, []int{1, 3}, got)
+	assert.Len(t, err.(*util.MultiError).Unwrap(), 2)
+
+	got, err = util.TryMap([]string{"1"}, strconv.Atoi)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, got)
+}
+
+func TestFilterErr(t *testing.T) {
+	positive := func(v string) (bool, error) {
+		i, err := strconv.Atoi(v)
+		return i > 0, err
+	}
+	got, err := util.FilterErr([]string{"1", "-2", "3"}, positive)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, got)
+
+	got, err = util.FilterErr([]string{"1", "x", "y"}, positive, util.CollectErrors)
+	assert.Nil(t, got)
+	assert.Len(t, err.(*util.MultiError).Unwrap(), 2)
+}
+
+func TestReduceErr(t *testing.T) {
+	sum := func(r int, v string) (int, error) {
+		i, err := strconv.Atoi(v)
+		return r + i, err
+	}
+	got, err := util.ReduceErr([]string{"1", "2", "3"}, sum)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, got)
+
+	got, err = util.ReduceErr([]string{"1", "x", "3"}, sum)
+	assert.Error(t, err)
+	assert.Equal(t, 1, got)
+
+	got, err = util.ReduceErr([]string{"1", "x", "3"}, sum, util.CollectErrors)
+	assert.Error(t, err)
+	assert.Equal(t, 4, got)
+}
+
+func TestEachErr(t *testing.T) {
+	var seen []int
+	f := func(v int) error {
+		seen = append(seen, v)
+		if v%2 == 0 {
+			return fmt.Errorf("even %d", v)
+		}
+		return nil
+	}
+	assert.EqualError(t, util.EachErr([]int{1, 2, 3, 4}, f), "even 2")
+	assert.Equal(t, []int{1, 2}, seen)
+
+	seen = nil
+	assert.EqualError(t, util.EachErr([]int{1, 2, 3, 4}, f, util.CollectErrors), "even 2\neven 4")
+	assert.Equal(t, []int{1, 2, 3, 4}, seen)
+
+	assert.NoError(t, util.EachErr([]int{1, 3}, f))
+}
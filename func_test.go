@@ -2,6 +2,7 @@ package util_test
 
 import (
 	"github.com/stretchr/testify/assert"
+	"math"
 	"strconv"
 	"testing"
 
@@ -77,3 +78,209 @@ func TestSort(t *testing.T) {
 		})
 	}
 }
+
+type testUser struct {
+	Name string
+	Age  int
+}
+
+func testUsers() []testUser {
+	return []testUser{{"c", 20}, {"a", 30}, {"b", 20}, {"d", 10}}
+}
+
+func TestSortBy(t *testing.T) {
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, util.SortDesc([]int{2, 5, 3, 4, 1}))
+
+	assert.Equal(t, []testUser{{"a", 30}, {"b", 20}, {"c", 20}, {"d", 10}}, util.SortBy(testUsers(), func(a, b testUser) bool {
+		return a.Name < b.Name
+	}))
+
+	assert.Equal(t, []testUser{{"d", 10}, {"c", 20}, {"b", 20}, {"a", 30}}, util.SortStableBy(testUsers(), func(a, b testUser) bool {
+		return a.Age < b.Age
+	}))
+
+	age := func(u testUser) int {
+		return u.Age
+	}
+	assert.Equal(t, []int{10, 20, 20, 30}, util.Map(util.SortByKey(testUsers(), age), age))
+	assert.Equal(t, []int{30, 20, 20, 10}, util.Map(util.SortByKeyDesc(testUsers(), age), age))
+	assert.Equal(t, []testUser{{"d", 10}, {"c", 20}, {"b", 20}, {"a", 30}}, util.SortStableByKey(testUsers(), age))
+	assert.Equal(t, []testUser{{"a", 30}, {"c", 20}, {"b", 20}, {"d", 10}}, util.SortStableByKeyDesc(testUsers(), age))
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := util.GroupBy(testUsers(), func(u testUser) int {
+		return u.Age
+	})
+	assert.Equal(t, map[int][]testUser{
+		10: {{"d", 10}},
+		20: {{"c", 20}, {"b", 20}},
+		30: {{"a", 30}},
+	}, groups)
+	assert.Equal(t, map[int][]int{}, util.GroupBy([]int{}, func(i int) int {
+		return i
+	}))
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	g := util.GroupByOrdered([]string{"bb", "a", "cc", "d", "eee"}, func(v string) int {
+		return len(v)
+	})
+	assert.Equal(t, []int{2, 1, 3}, g.Keys())
+	assert.Equal(t, 3, g.Len())
+	assert.Equal(t, []string{"bb", "cc"}, g.Get(2))
+	assert.Nil(t, g.Get(4))
+	assert.Equal(t, []string{"eee"}, g.Map()[3])
+
+	var keys []int
+	g.Each(func(k int, vs []string) bool {
+		keys = append(keys, k)
+		return k != 1
+	})
+	assert.Equal(t, []int{2, 1}, keys)
+}
+
+func TestPartition(t *testing.T) {
+	yes, no := util.Partition([]int{1, 2, 3, 4, 5}, func(i int) bool {
+		return i%2 == 0
+	})
+	assert.Equal(t, []int{2, 4}, yes)
+	assert.Equal(t, []int{1, 3, 5}, no)
+
+	yes, no = util.Partition([]int{}, func(i int) bool {
+		return true
+	})
+	assert.Equal(t, []int{}, yes)
+	assert.Equal(t, []int{}, no)
+}
+
+func TestKeyBy(t *testing.T) {
+	assert.Equal(t, map[string]testUser{"a": {"a", 30}, "b": {"b", 20}, "c": {"c", 20}, "d": {"d", 10}}, util.KeyBy(testUsers(), func(u testUser) string {
+		return u.Name
+	}))
+}
+
+func TestCountBy(t *testing.T) {
+	assert.Equal(t, map[int]int{10: 1, 20: 2, 30: 1}, util.CountBy(testUsers(), func(u testUser) int {
+		return u.Age
+	}))
+}
+
+func TestChunk(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, util.Chunk(arr, 2))
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}}, util.Chunk(arr, 10))
+	assert.Equal(t, [][]int{{1}, {2}, {3}, {4}, {5}}, util.Chunk(arr, 0))
+	assert.Equal(t, [][]int{}, util.Chunk([]int{}, 2))
+
+	// 分组是原数组的视图，append 不会覆盖后面的元素
+	chunks := util.Chunk(arr, 2)
+	chunks[0][0] = 10
+	assert.Equal(t, 10, arr[0])
+	_ = append(chunks[0], 100)
+	assert.Equal(t, 3, arr[2])
+
+	// 分批求和
+	assert.Equal(t, []int{12, 7, 5}, util.Map(util.Chunk(arr, 2), func(c []int) int {
+		return util.Sum(c, func(i int) int {
+			return i
+		})
+	}))
+}
+
+func TestChunkBy(t *testing.T) {
+	assert.Equal(t, [][]int{{1, 2, 3}, {5, 6}, {8}}, util.ChunkBy([]int{1, 2, 3, 5, 6, 8}, func(prev, cur int) bool {
+		return cur == prev+1
+	}))
+	assert.Equal(t, [][]int{}, util.ChunkBy([]int{}, func(prev, cur int) bool {
+		return true
+	}))
+}
+
+func TestWindows(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, util.Windows(arr, 3, 1))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, util.Windows(arr, 2, 2))
+	assert.Equal(t, [][]int{}, util.Windows(arr, 6, 1))
+
+	// 移动平均
+	assert.Equal(t, []float64{1.5, 2.5, 3.5, 4.5}, util.Map(util.Windows(arr, 2, 1), func(w []int) float64 {
+		return float64(util.Reduce(w, func(sum, v int) int {
+			return sum + v
+		})) / float64(len(w))
+	}))
+}
+
+func TestIntersect(t *testing.T) {
+	assert.Equal(t, []int{3, 2}, util.Intersect([]int{3, 1, 2, 3, 2}, []int{2, 3, 4}))
+	assert.Equal(t, []int{}, util.Intersect([]int{1}, []int{}))
+	assert.Equal(t, []testUser{{"c", 20}, {"d", 10}}, util.IntersectBy(testUsers(), []testUser{{"x", 20}, {"y", 10}}, func(u testUser) int {
+		return u.Age
+	}))
+}
+
+func TestUnion(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 2, 4, 5}, util.Union([]int{3, 1, 3}, []int{2, 1, 4}, []int{5}))
+	assert.Equal(t, []int{}, util.Union[int]())
+	assert.Equal(t, []testUser{{"c", 20}, {"a", 30}, {"d", 10}, {"e", 40}}, util.UnionBy(func(u testUser) int {
+		return u.Age
+	}, testUsers(), []testUser{{"e", 40}}))
+}
+
+func TestSymmetricDiff(t *testing.T) {
+	assert.Equal(t, []int{1, 4, 5}, util.SymmetricDiff([]int{1, 2, 3, 1}, []int{4, 2, 3, 5}))
+	assert.Equal(t, []int{}, util.SymmetricDiff([]int{1, 2}, []int{2, 1}))
+	assert.Equal(t, []testUser{{"a", 30}, {"x", 50}}, util.SymmetricDiffBy(testUsers(), []testUser{{"x", 50}, {"y", 10}, {"z", 20}}, func(u testUser) int {
+		return u.Age
+	}))
+}
+
+func TestDiffBy(t *testing.T) {
+	assert.Equal(t, []testUser{{"a", 30}, {"d", 10}}, util.DiffBy(testUsers(), []testUser{{"x", 20}}, func(u testUser) int {
+		return u.Age
+	}))
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	arr1, arr2 := make([]int, 100000), make([]int, 100000)
+	for i := range arr1 {
+		arr1[i] = i
+		arr2[i] = i * 2
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		util.Intersect(arr1, arr2)
+	}
+}
+
+func TestFold(t *testing.T) {
+	product := func(r, v int) int {
+		return r * v
+	}
+	assert.Equal(t, 24, util.Fold([]int{1, 2, 3, 4}, 1, product))
+	assert.Equal(t, 1, util.Fold([]int{}, 1, product))
+	assert.Equal(t, 1, util.Fold([]int{3, 1, 2}, math.MaxInt, func(r, v int) int {
+		if v < r {
+			return v
+		}
+		return r
+	}))
+
+	concat := func(r string, v int) string {
+		return r + strconv.Itoa(v)
+	}
+	assert.Equal(t, ">123", util.Fold([]int{1, 2, 3}, ">", concat))
+	assert.Equal(t, ">321", util.FoldRight([]int{1, 2, 3}, ">", concat))
+}
+
+func TestScan(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, util.Scan([]int{1, 2, 3, 4}, 0, func(r, v int) int {
+		return r + v
+	}))
+	assert.Equal(t, []string{"a", "ab"}, util.Scan([]string{"a", "b"}, "", func(r, v string) string {
+		return r + v
+	}))
+	assert.Equal(t, []int{}, util.Scan([]int{}, 0, func(r, v int) int {
+		return r + v
+	}))
+}
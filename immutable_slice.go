@@ -0,0 +1,329 @@
+package util
+
+import (
+	"database/sql/driver"
+	"io"
+	"math/rand"
+)
+
+// ImmutableSlice 不可变的集合，方法与 Slice 一致
+// 所有操作都会返回新的集合，不会修改原集合，也不会修改创建时传入的数组
+// 返回的切片与元素指针都是副本，修改它们不会影响集合
+// 零值为空集合，可以直接用于 json 解码与数据库读取
+type ImmutableSlice[T comparable] struct {
+	s *Slice[T]
+}
+
+// NewImmutableSlice 新建一个不可变集合，会复制传入的数组
+func NewImmutableSlice[T comparable](vs []T) *ImmutableSlice[T] {
+	return NewSlice(vs).Immutable()
+}
+
+// Immutable 以集合当前元素的副本新建一个不可变集合
+func (s *Slice[T]) Immutable() *ImmutableSlice[T] {
+	c := s.Copy()
+	c.err = s.err
+	return &ImmutableSlice[T]{s: c}
+}
+
+// Mutable 返回可以原地修改的 Slice 副本
+func (s *ImmutableSlice[T]) Mutable() *Slice[T] {
+	return s.clone()
+}
+
+// get 返回内部的集合，零值时返回空集合
+func (s *ImmutableSlice[T]) get() *Slice[T] {
+	if s.s == nil {
+		return NewSlice([]T{})
+	}
+	return s.s
+}
+
+// clone 复制内部的集合，包括随机源、数据库格式与错误
+func (s *ImmutableSlice[T]) clone() *Slice[T] {
+	c := s.get().Copy()
+	c.err = s.get().err
+	return c
+}
+
+// with 在副本上执行 f，返回新的不可变集合
+func (s *ImmutableSlice[T]) with(f func(c *Slice[T]) *Slice[T]) *ImmutableSlice[T] {
+	return &ImmutableSlice[T]{s: f(s.clone())}
+}
+
+// wrap 包装一个不会再被修改的集合
+func (s *ImmutableSlice[T]) wrap(c *Slice[T]) *ImmutableSlice[T] {
+	return &ImmutableSlice[T]{s: c}
+}
+
+func (s *ImmutableSlice[T]) slices(ss []*ImmutableSlice[T]) []*Slice[T] {
+	return Map(ss, func(i *ImmutableSlice[T]) *Slice[T] {
+		return i.get()
+	})
+}
+
+// Set 返回使用 vs 副本的新集合
+func (s *ImmutableSlice[T]) Set(vs []T) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Set(append([]T{}, vs...))
+	})
+}
+
+// Slice 返回集合中数据的副本
+func (s *ImmutableSlice[T]) Slice() []T {
+	return append([]T{}, s.get().Slice()...)
+}
+
+func (s *ImmutableSlice[T]) Unique() *ImmutableSlice[T] {
+	return s.with((*Slice[T]).Unique)
+}
+
+// Reset 返回一个空集合
+func (s *ImmutableSlice[T]) Reset() *ImmutableSlice[T] {
+	return s.Set([]T{})
+}
+
+func (s *ImmutableSlice[T]) Each(f func(T) T) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Each(f)
+	})
+}
+
+func (s *ImmutableSlice[T]) Filter(f func(T) bool) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Filter(f)
+	})
+}
+
+func (s *ImmutableSlice[T]) Reject(f func(T) bool) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Reject(f)
+	})
+}
+
+// First 返回第一个元素副本的指针，集合为空时返回 nil
+func (s *ImmutableSlice[T]) First() *T {
+	return copyPtr(s.get().First())
+}
+
+// Last 返回最后一个元素副本的指针，集合为空时返回 nil
+func (s *ImmutableSlice[T]) Last() *T {
+	return copyPtr(s.get().Last())
+}
+
+func (s *ImmutableSlice[T]) Empty() bool {
+	return s.get().Empty()
+}
+
+// Index 返回 i 下标对应元素副本的指针，下标不存在时返回 nil
+func (s *ImmutableSlice[T]) Index(i int) *T {
+	return copyPtr(s.get().Index(i))
+}
+
+func (s *ImmutableSlice[T]) Copy() *ImmutableSlice[T] {
+	return s.with(identity[*Slice[T]])
+}
+
+func (s *ImmutableSlice[T]) Merge(ss ...*ImmutableSlice[T]) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Merge(s.slices(ss)...)
+	})
+}
+
+func (s *ImmutableSlice[T]) MergeSlice(arr []T) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.MergeSlice(arr)
+	})
+}
+
+func (s *ImmutableSlice[T]) Reverse() *ImmutableSlice[T] {
+	return s.with((*Slice[T]).Reverse)
+}
+
+// SetRand 返回使用随机源 r 的新集合
+func (s *ImmutableSlice[T]) SetRand(r *rand.Rand) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.SetRand(r)
+	})
+}
+
+func (s *ImmutableSlice[T]) Random() *T {
+	return copyPtr(s.get().Random())
+}
+
+func (s *ImmutableSlice[T]) Shuffle() *ImmutableSlice[T] {
+	return s.with((*Slice[T]).Shuffle)
+}
+
+func (s *ImmutableSlice[T]) Sample(n int) *ImmutableSlice[T] {
+	return s.wrap(s.get().Sample(n))
+}
+
+func (s *ImmutableSlice[T]) Contains(v T) bool {
+	return s.get().Contains(v)
+}
+
+func (s *ImmutableSlice[T]) ContainsAll(vs ...T) bool {
+	return s.get().ContainsAll(vs...)
+}
+
+func (s *ImmutableSlice[T]) ContainsCount(v T) int {
+	return s.get().ContainsCount(v)
+}
+
+func (s *ImmutableSlice[T]) Push(vs ...T) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.Push(vs...)
+	})
+}
+
+// Pop 返回去掉最后一个元素的新集合与最后一个元素，集合为空时元素为 nil
+func (s *ImmutableSlice[T]) Pop() (*ImmutableSlice[T], *T) {
+	c := s.clone()
+	v := c.Pop()
+	return s.wrap(c), v
+}
+
+func (s *ImmutableSlice[T]) Len() int {
+	return s.get().Len()
+}
+
+func (s *ImmutableSlice[T]) Equal(s2 *ImmutableSlice[T]) bool {
+	return s.get().Equal(s2.get())
+}
+
+func (s *ImmutableSlice[T]) JSON() ([]byte, error) {
+	return s.get().JSON()
+}
+
+func (s *ImmutableSlice[T]) JSONString() (string, error) {
+	return s.get().JSONString()
+}
+
+func (s *ImmutableSlice[T]) Pretty() string {
+	return s.get().Pretty()
+}
+
+func (s *ImmutableSlice[T]) MarshalJSON() ([]byte, error) {
+	return s.get().MarshalJSON()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，只用于解码时初始化集合
+func (s *ImmutableSlice[T]) UnmarshalJSON(data []byte) error {
+	c := s.clone()
+	if err := c.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.s = c
+	return nil
+}
+
+func (s *ImmutableSlice[T]) Diff(c2 *ImmutableSlice[T]) *ImmutableSlice[T] {
+	return s.wrap(s.get().Diff(c2.get()))
+}
+
+func (s *ImmutableSlice[T]) SortBy(less func(a, b T) bool) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.SortBy(less)
+	})
+}
+
+func (s *ImmutableSlice[T]) SortStable(less func(a, b T) bool) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.SortStable(less)
+	})
+}
+
+func (s *ImmutableSlice[T]) Partition(f func(T) bool) (*ImmutableSlice[T], *ImmutableSlice[T]) {
+	yes, no := s.get().Partition(f)
+	return s.wrap(yes), s.wrap(no)
+}
+
+func (s *ImmutableSlice[T]) Chunk(size int) []*ImmutableSlice[T] {
+	return Map(s.get().Chunk(size), s.wrap)
+}
+
+func (s *ImmutableSlice[T]) Windows(size, step int) []*ImmutableSlice[T] {
+	return Map(s.get().Windows(size, step), s.wrap)
+}
+
+func (s *ImmutableSlice[T]) Reduce(init T, f func(acc T, v T) T) T {
+	return s.get().Reduce(init, f)
+}
+
+func (s *ImmutableSlice[T]) Accumulate(init T, f func(acc T, v T) T) *ImmutableSlice[T] {
+	return s.wrap(s.get().Accumulate(init, f))
+}
+
+func (s *ImmutableSlice[T]) Err() error {
+	return s.get().Err()
+}
+
+func (s *ImmutableSlice[T]) EachErr(f func(T) (T, error), mode ...ErrMode) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.EachErr(f, mode...)
+	})
+}
+
+func (s *ImmutableSlice[T]) FilterErr(f func(T) (bool, error), mode ...ErrMode) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.FilterErr(f, mode...)
+	})
+}
+
+func (s *ImmutableSlice[T]) MapErr(f func(T) (T, error), mode ...ErrMode) *ImmutableSlice[T] {
+	return s.wrap(s.get().MapErr(f, mode...))
+}
+
+func (s *ImmutableSlice[T]) ToSet() *Set[T] {
+	return s.get().ToSet()
+}
+
+func (s *ImmutableSlice[T]) Intersect(c2 *ImmutableSlice[T]) *ImmutableSlice[T] {
+	return s.wrap(s.get().Intersect(c2.get()))
+}
+
+func (s *ImmutableSlice[T]) Union(ss ...*ImmutableSlice[T]) *ImmutableSlice[T] {
+	return s.wrap(s.get().Union(s.slices(ss)...))
+}
+
+func (s *ImmutableSlice[T]) SymmetricDiff(c2 *ImmutableSlice[T]) *ImmutableSlice[T] {
+	return s.wrap(s.get().SymmetricDiff(c2.get()))
+}
+
+func (s *ImmutableSlice[T]) Map(f func(T) T) *ImmutableSlice[T] {
+	return s.wrap(s.get().Map(f))
+}
+
+func (s *ImmutableSlice[T]) WriteCSV(w io.Writer, opts ...CSVOptions) error {
+	return s.get().WriteCSV(w, opts...)
+}
+
+func (s *ImmutableSlice[T]) WriteNDJSON(w io.Writer) error {
+	return s.get().WriteNDJSON(w)
+}
+
+// SetSQLEncoding 返回使用数据库格式 e 的新集合
+func (s *ImmutableSlice[T]) SetSQLEncoding(e SQLEncoding) *ImmutableSlice[T] {
+	return s.with(func(c *Slice[T]) *Slice[T] {
+		return c.SetSQLEncoding(e)
+	})
+}
+
+// Value 实现 driver.Valuer，nil 集合保存为数据库中的 NULL
+func (s *ImmutableSlice[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return s.get().Value()
+}
+
+// Scan 实现 sql.Scanner，只用于从数据库读取时初始化集合
+func (s *ImmutableSlice[T]) Scan(src any) error {
+	c := s.clone()
+	if err := c.Scan(src); err != nil {
+		return err
+	}
+	s.s = c
+	return nil
+}
@@ -0,0 +1,137 @@
+package util_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestImmutableSlice_NeverModifiesInput(t *testing.T) {
+	// 预留容量，如果有 append 写入原数组会被检测到
+	backing := make([]int, 5, 10)
+	copy(backing, []int{5, 3, 1, 3, 2})
+	full := backing[:10]
+	snapshot := append([]int{}, full...)
+
+	s := util.NewImmutableSlice(backing)
+	other := util.NewImmutableSlice([]int{3, 4})
+	square := func(v int) int {
+		return v * v
+	}
+	even := func(v int) bool {
+		return v%2 == 0
+	}
+	less := func(a, b int) bool {
+		return a < b
+	}
+	noErr := func(v int) (int, error) {
+		return v + 1, nil
+	}
+
+	results := map[string]*util.ImmutableSlice[int]{
+		"Set":           s.Set([]int{7}),
+		"Unique":        s.Unique(),
+		"Reset":         s.Reset(),
+		"Each":          s.Each(square),
+		"Filter":        s.Filter(even),
+		"Reject":        s.Reject(even),
+		"Copy":          s.Copy(),
+		"Merge":         s.Merge(other),
+		"MergeSlice":    s.MergeSlice([]int{9}),
+		"Reverse":       s.Reverse(),
+		"Shuffle":       s.SetRand(newRand()).Shuffle(),
+		"Sample":        s.Sample(2),
+		"Push":          s.Push(8, 9),
+		"Diff":          s.Diff(other),
+		"SortBy":        s.SortBy(less),
+		"SortStable":    s.SortStable(less),
+		"Accumulate":    s.Accumulate(0, func(acc, v int) int { return acc + v }),
+		"EachErr":       s.EachErr(noErr),
+		"FilterErr":     s.FilterErr(func(v int) (bool, error) { return v > 2, nil }),
+		"MapErr":        s.MapErr(noErr),
+		"Intersect":     s.Intersect(other),
+		"Union":         s.Union(other),
+		"SymmetricDiff": s.SymmetricDiff(other),
+		"Map":           s.Map(square),
+	}
+	popped, last := s.Pop()
+	results["Pop"] = popped
+	yes, no := s.Partition(even)
+	results["Partition yes"], results["Partition no"] = yes, no
+	for _, c := range s.Chunk(2) {
+		results["Chunk"] = c.Push(100)
+	}
+	for _, w := range s.Windows(2, 1) {
+		results["Windows"] = w.Push(100)
+	}
+
+	assert.Equal(t, 2, *last)
+	assert.Equal(t, []int{25, 9, 1, 9, 4}, results["Each"].Slice())
+	assert.Equal(t, []int{5, 3, 1, 3, 2, 8, 9}, results["Push"].Slice())
+	assert.Equal(t, []int{1, 2, 3, 3, 5}, results["SortBy"].Slice())
+	assert.Equal(t, []int{5, 3, 1, 3}, results["Pop"].Slice())
+	assert.Equal(t, []int{2, 100}, results["Chunk"].Slice())
+
+	// 返回的切片与指针都是副本
+	s.Slice()[0] = 100
+	*s.First() = 100
+	*s.Index(1) = 100
+	*s.Last() = 100
+
+	assert.Equal(t, snapshot, full)
+	assert.Equal(t, []int{5, 3, 1, 3, 2}, s.Slice())
+	assert.Equal(t, []int{3, 4}, other.Slice())
+
+	// 创建后修改原数组不会影响集合
+	backing[0] = 42
+	assert.Equal(t, 5, *s.First())
+}
+
+func TestImmutableSlice(t *testing.T) {
+	s := util.NewImmutableSlice([]string{"a", "b"})
+	assert.Equal(t, 2, s.Len())
+	assert.False(t, s.Empty())
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.ContainsAll("a", "b"))
+	assert.Equal(t, 1, s.ContainsCount("b"))
+	assert.True(t, s.Equal(util.NewImmutableSlice([]string{"a", "b"})))
+	assert.Equal(t, "ab", s.Reduce("", func(acc, v string) string {
+		return acc + v
+	}))
+	assert.NoError(t, s.Err())
+	assert.Equal(t, 2, s.ToSet().Len())
+
+	m := s.Mutable().Push("c")
+	assert.Equal(t, []string{"a", "b", "c"}, m.Slice())
+	assert.Equal(t, []string{"a", "b"}, s.Slice())
+	assert.Equal(t, []string{"a", "b", "c"}, m.Immutable().Slice())
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, string(b))
+
+	var decoded struct {
+		Tags  *util.ImmutableSlice[string] `json:"tags"`
+		Empty util.ImmutableSlice[string]  `json:"empty"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"tags":["x"]}`), &decoded))
+	assert.Equal(t, []string{"x"}, decoded.Tags.Slice())
+	assert.Equal(t, []string{}, decoded.Empty.Slice())
+	assert.Nil(t, decoded.Empty.First())
+
+	v, err := s.SetSQLEncoding(util.SQLCommaText).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b", v)
+	var scanned util.ImmutableSlice[string]
+	assert.NoError(t, scanned.Scan(`["y"]`))
+	assert.Equal(t, []string{"y"}, scanned.Slice())
+}
+
+func TestImmutableSlice_NilValue(t *testing.T) {
+	var s *util.ImmutableSlice[int]
+	v, err := s.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
@@ -0,0 +1,273 @@
+package util
+
+// Iter 惰性迭代器，每次调用 Next 时才会计算下一个元素
+// 链式调用的各个步骤会合并在一次遍历中完成，不会产生中间切片
+// 迭代器只能被消费一次
+type Iter[T any] struct {
+	next func() (T, bool)
+}
+
+// Pair 两个值的组合，用于 Zip 等操作
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// IterFromFunc 使用生成方法新建一个迭代器，next 返回 false 表示迭代结束
+func IterFromFunc[T any](next func() (T, bool)) *Iter[T] {
+	return &Iter[T]{next: next}
+}
+
+// IterFromSlice 按顺序迭代切片中的元素
+func IterFromSlice[T any](arr []T) *Iter[T] {
+	i := 0
+	return IterFromFunc(func() (T, bool) {
+		if i >= len(arr) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return arr[i-1], true
+	})
+}
+
+// IterFromQueue 从队头到队尾迭代队列中的元素，不会弹出元素
+func IterFromQueue[T any](q *Queue[T]) *Iter[T] {
+	p := q.head
+	return IterFromFunc(func() (T, bool) {
+		if p == nil {
+			var zero T
+			return zero, false
+		}
+		v := p.val
+		p = p.next
+		return v, true
+	})
+}
+
+// IterFromStack 按照出栈的顺序(从栈顶到栈底)迭代栈中的元素，不会弹出元素
+// 迭代过程中出栈的元素会被跳过，新入栈的元素不会被迭代
+func IterFromStack[T any](s *Stack[T]) *Iter[T] {
+	i := s.Len()
+	return IterFromFunc(func() (T, bool) {
+		if i > s.Len() {
+			i = s.Len()
+		}
+		if i <= 0 {
+			var zero T
+			return zero, false
+		}
+		i--
+		return s.items[i], true
+	})
+}
+
+// IterFromChan 迭代 channel 中的元素，直到 channel 被关闭
+func IterFromChan[T any](ch <-chan T) *Iter[T] {
+	return IterFromFunc(func() (T, bool) {
+		v, ok := <-ch
+		return v, ok
+	})
+}
+
+// Next 返回下一个元素，迭代结束时第二个返回值为 false
+func (it *Iter[T]) Next() (T, bool) {
+	return it.next()
+}
+
+// Map 使用传入的方法处理每一个元素
+// 方法不能再定义新的泛型，需要改变元素类型时使用 IterMap
+func (it *Iter[T]) Map(f func(T) T) *Iter[T] {
+	return IterMap(it, f)
+}
+
+// Filter 只保留 f 返回 true 的元素
+func (it *Iter[T]) Filter(f func(T) bool) *Iter[T] {
+	return IterFromFunc(func() (T, bool) {
+		for {
+			v, ok := it.next()
+			if !ok || f(v) {
+				return v, ok
+			}
+		}
+	})
+}
+
+// Reject 与 Filter 相反，去掉 f 返回 true 的元素
+func (it *Iter[T]) Reject(f func(T) bool) *Iter[T] {
+	return it.Filter(func(v T) bool {
+		return !f(v)
+	})
+}
+
+// Take 只保留前 n 个元素
+func (it *Iter[T]) Take(n int) *Iter[T] {
+	return IterFromFunc(func() (T, bool) {
+		if n <= 0 {
+			var zero T
+			return zero, false
+		}
+		n--
+		return it.next()
+	})
+}
+
+// Skip 跳过前 n 个元素
+func (it *Iter[T]) Skip(n int) *Iter[T] {
+	return IterFromFunc(func() (T, bool) {
+		for ; n > 0; n-- {
+			if _, ok := it.next(); !ok {
+				n = 0
+				var zero T
+				return zero, false
+			}
+		}
+		return it.next()
+	})
+}
+
+// TakeWhile 保留元素直到 f 第一次返回 false
+func (it *Iter[T]) TakeWhile(f func(T) bool) *Iter[T] {
+	done := false
+	return IterFromFunc(func() (T, bool) {
+		if !done {
+			v, ok := it.next()
+			if ok && f(v) {
+				return v, true
+			}
+			done = true
+		}
+		var zero T
+		return zero, false
+	})
+}
+
+// DropWhile 跳过元素直到 f 第一次返回 false，之后的元素全部保留
+func (it *Iter[T]) DropWhile(f func(T) bool) *Iter[T] {
+	dropped := false
+	return IterFromFunc(func() (T, bool) {
+		if dropped {
+			return it.next()
+		}
+		dropped = true
+		for {
+			v, ok := it.next()
+			if !ok || !f(v) {
+				return v, ok
+			}
+		}
+	})
+}
+
+// Each 遍历剩余的元素，f 返回 false 时停止
+func (it *Iter[T]) Each(f func(T) bool) {
+	for {
+		v, ok := it.next()
+		if !ok || !f(v) {
+			return
+		}
+	}
+}
+
+// Collect 以切片的形式返回剩余的元素
+func (it *Iter[T]) Collect() []T {
+	ret := []T{}
+	it.Each(func(v T) bool {
+		ret = append(ret, v)
+		return true
+	})
+	return ret
+}
+
+// Count 返回剩余元素的个数
+func (it *Iter[T]) Count() int {
+	var n int
+	it.Each(func(T) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// First 返回下一个元素，迭代结束时返回 nil
+func (it *Iter[T]) First() *T {
+	v, ok := it.next()
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// IterMap 使用传入的方法处理每一个元素，可以改变元素的类型
+func IterMap[T, U any](it *Iter[T], f func(T) U) *Iter[U] {
+	return IterFromFunc(func() (U, bool) {
+		v, ok := it.next()
+		if !ok {
+			var zero U
+			return zero, false
+		}
+		return f(v), true
+	})
+}
+
+// IterFlatMap 每个元素映射为一个切片，并按顺序展开
+func IterFlatMap[T, U any](it *Iter[T], f func(T) []U) *Iter[U] {
+	var buf []U
+	return IterFromFunc(func() (U, bool) {
+		for len(buf) == 0 {
+			v, ok := it.next()
+			if !ok {
+				var zero U
+				return zero, false
+			}
+			buf = f(v)
+		}
+		v := buf[0]
+		buf = buf[1:]
+		return v, true
+	})
+}
+
+// IterChunk 每 size 个元素组成一个切片，最后一组可能不足 size 个
+// size 小于 1 时按 1 处理
+func IterChunk[T any](it *Iter[T], size int) *Iter[[]T] {
+	if size < 1 {
+		size = 1
+	}
+	return IterFromFunc(func() ([]T, bool) {
+		chunk := make([]T, 0, size)
+		for len(chunk) < size {
+			v, ok := it.next()
+			if !ok {
+				break
+			}
+			chunk = append(chunk, v)
+		}
+		return chunk, len(chunk) > 0
+	})
+}
+
+// IterZip 将两个迭代器的元素按顺序组合，任意一个迭代结束时结束
+func IterZip[A, B any](a *Iter[A], b *Iter[B]) *Iter[Pair[A, B]] {
+	return IterFromFunc(func() (Pair[A, B], bool) {
+		va, ok := a.next()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		vb, ok := b.next()
+		if !ok {
+			return Pair[A, B]{}, false
+		}
+		return Pair[A, B]{va, vb}, true
+	})
+}
+
+// IterReduce 遍历剩余的元素，返回一个值，与 Reduce 一样从 R 的零值开始
+func IterReduce[T, R any](it *Iter[T], f func(R, T) R) R {
+	var ret R
+	it.Each(func(v T) bool {
+		ret = f(ret, v)
+		return true
+	})
+	return ret
+}
@@ -0,0 +1,148 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func isEven(v int) bool {
+	return v%2 == 0
+}
+
+func double(v int) int {
+	return v * 2
+}
+
+func TestIterFrom(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, util.IterFromSlice([]int{1, 2, 3}).Collect())
+	assert.Equal(t, []int{}, util.IterFromSlice([]int{}).Collect())
+
+	q := util.NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+	assert.Equal(t, []int{1, 2}, util.IterFromQueue(q).Collect())
+	assert.Equal(t, 2, q.Len())
+
+	s := util.NewStack[int]()
+	s.Push(1)
+	s.Push(2)
+	assert.Equal(t, []int{2, 1}, util.IterFromStack(s).Collect())
+	assert.Equal(t, 2, s.Len())
+
+	// 迭代过程中出栈不会越界
+	s.Push(3)
+	it := util.IterFromStack(s)
+	v, _ := it.Next()
+	assert.Equal(t, 3, v)
+	s.Pop()
+	s.Pop()
+	assert.Equal(t, []int{1}, it.Collect())
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	close(ch)
+	assert.Equal(t, []int{1, 2}, util.IterFromChan(ch).Collect())
+
+	i := 0
+	naturals := util.IterFromFunc(func() (int, bool) {
+		i++
+		return i, true
+	})
+	assert.Equal(t, []int{1, 2, 3}, naturals.Take(3).Collect())
+}
+
+func TestIter_Chain(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	assert.Equal(t, []int{4, 8, 12, 16}, util.IterFromSlice(arr).Filter(isEven).Map(double).Collect())
+	assert.Equal(t, []int{1, 3, 5, 7}, util.IterFromSlice(arr).Reject(isEven).Collect())
+	assert.Equal(t, []int{3, 4}, util.IterFromSlice(arr).Skip(2).Take(2).Collect())
+	assert.Equal(t, []int{}, util.IterFromSlice(arr).Skip(10).Collect())
+	assert.Equal(t, []int{}, util.IterFromSlice(arr).Take(0).Collect())
+
+	lt4 := func(v int) bool {
+		return v < 4
+	}
+	assert.Equal(t, []int{1, 2, 3}, util.IterFromSlice(arr).TakeWhile(lt4).Collect())
+	assert.Equal(t, []int{4, 5, 6, 7, 8}, util.IterFromSlice(arr).DropWhile(lt4).Collect())
+	assert.Equal(t, []int{1, 2}, util.IterFromSlice([]int{5, 1, 2}).DropWhile(func(v int) bool {
+		return v > 3
+	}).Collect())
+
+	assert.Equal(t, 4, util.IterFromSlice(arr).Filter(isEven).Count())
+	assert.Equal(t, 2, *util.IterFromSlice(arr).Filter(isEven).First())
+	assert.Nil(t, util.IterFromSlice(arr).Filter(func(int) bool { return false }).First())
+}
+
+func TestIter_TypeChanging(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []string{"1", "2", "3"}, util.IterMap(util.IterFromSlice(arr).Take(3), strconv.Itoa).Collect())
+
+	assert.Equal(t, []int{1, 2, 2}, util.IterFlatMap(util.IterFromSlice([]int{1, 0, 2}), func(v int) []int {
+		ret := []int{}
+		for i := 0; i < v; i++ {
+			ret = append(ret, v)
+		}
+		return ret
+	}).Collect())
+
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, util.IterChunk(util.IterFromSlice(arr), 2).Collect())
+	assert.Equal(t, [][]int{}, util.IterChunk(util.IterFromSlice([]int{}), 2).Collect())
+
+	assert.Equal(t, []util.Pair[int, string]{{1, "a"}, {2, "b"}}, util.IterZip(util.IterFromSlice(arr), util.IterFromSlice([]string{"a", "b"})).Collect())
+
+	assert.Equal(t, 15, util.IterReduce(util.IterFromSlice(arr), func(sum, v int) int {
+		return sum + v
+	}))
+}
+
+func benchData() []int {
+	arr := make([]int, 10000)
+	for i := range arr {
+		arr[i] = i
+	}
+	return arr
+}
+
+func BenchmarkSlice_Chain(b *testing.B) {
+	arr := benchData()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		util.NewSlice(arr).Copy().Filter(isEven).Map(double).Reject(func(v int) bool {
+			return v%3 == 0
+		}).Slice()
+	}
+}
+
+func BenchmarkIter_Chain(b *testing.B) {
+	arr := benchData()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		util.IterFromSlice(arr).Filter(isEven).Map(double).Reject(func(v int) bool {
+			return v%3 == 0
+		}).Collect()
+	}
+}
+
+func BenchmarkSlice_ChainFirst(b *testing.B) {
+	arr := benchData()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		util.NewSlice(arr).Copy().Filter(isEven).Map(double).First()
+	}
+}
+
+func BenchmarkIter_ChainFirst(b *testing.B) {
+	arr := benchData()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		util.IterFromSlice(arr).Filter(isEven).Map(double).First()
+	}
+}
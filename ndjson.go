@@ -0,0 +1,58 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// NDJSON(每行一个 json 值)的流式读写，适合元素很多的情况
+
+// EachNDJSON 从 r 中逐个解码元素并调用 f，不会把所有元素读入内存
+// f 返回错误时停止并返回该错误
+func EachNDJSON[T any](r io.Reader, f func(T) error) error {
+	dec := json.NewDecoder(r)
+	for i := 0; ; i++ {
+		var v T
+		err := dec.Decode(&v)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("util: decode ndjson element %d: %w", i, err)
+		}
+		if err := f(v); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadNDJSON 从 r 中读取所有元素，以集合返回
+func ReadNDJSON[T comparable](r io.Reader) (*Slice[T], error) {
+	vs := []T{}
+	err := EachNDJSON(r, func(v T) error {
+		vs = append(vs, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewSlice(vs), nil
+}
+
+// WriteNDJSON 把数组元素逐个编码写入 w，每个元素一行
+func WriteNDJSON[T any](w io.Writer, arr []T) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for i, v := range arr {
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("util: encode ndjson element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// WriteNDJSON 把集合元素逐个编码写入 w，每个元素一行
+func (s *Slice[T]) WriteNDJSON(w io.Writer) error {
+	return WriteNDJSON(w, s.Slice())
+}
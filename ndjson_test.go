@@ -0,0 +1,66 @@
+package util_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, util.NewSlice([]string{"a", "<b>"}).WriteNDJSON(&buf))
+	assert.Equal(t, "\"a\"\n\"<b>\"\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, util.WriteNDJSON(&buf, testUsers()[:2]))
+	assert.Equal(t, "{\"Name\":\"c\",\"Age\":20}\n{\"Name\":\"a\",\"Age\":30}\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, util.WriteNDJSON(&buf, []int{}))
+	assert.Equal(t, "", buf.String())
+}
+
+func TestReadNDJSON(t *testing.T) {
+	s, err := util.ReadNDJSON[int](strings.NewReader("1\n2\n\n3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, s.Slice())
+
+	s, err = util.ReadNDJSON[int](strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, s.Slice())
+
+	_, err = util.ReadNDJSON[int](strings.NewReader("1\n\"x\"\n"))
+	assert.EqualError(t, err, "util: decode ndjson element 1: json: cannot unmarshal string into Go value of type int")
+
+	// 写入后再读取
+	var buf bytes.Buffer
+	in := util.NewSlice([]string{"a", "b\nc", ""})
+	assert.NoError(t, in.WriteNDJSON(&buf))
+	out, err := util.ReadNDJSON[string](&buf)
+	assert.NoError(t, err)
+	assert.True(t, in.Equal(out))
+}
+
+func TestEachNDJSON(t *testing.T) {
+	var users []testUser
+	err := util.EachNDJSON(strings.NewReader(`{"Name":"a","Age":1}
+{"Name":"b","Age":2}`), func(u testUser) error {
+		users = append(users, u)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []testUser{{"a", 1}, {"b", 2}}, users)
+
+	errStop := errors.New("stop")
+	var n int
+	err = util.EachNDJSON(strings.NewReader("1 2 3"), func(v int) error {
+		n++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelDo 使用最多 limit 个协程并发执行 f(ctx, 0) ~ f(ctx, n-1)
+// limit 小于 1 时使用 runtime.GOMAXPROCS(0)
+// 第一个错误出现或 ctx 被取消后不再执行新的任务，返回第一个错误
+func parallelDo(ctx context.Context, n, limit int, f func(ctx context.Context, i int) error) error {
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if limit > n {
+		limit = n
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next     int64 = -1
+		done     int64
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < limit; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n || ctx.Err() != nil {
+					return
+				}
+				if err := f(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				atomic.AddInt64(&done, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	// 任务执行完之前 ctx 被取消
+	if int(done) < n {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// ParallelEach 并发遍历数组，最多同时运行 limit 个协程，limit 小于 1 时使用 GOMAXPROCS
+// 出现第一个错误或 ctx 被取消时停止，返回该错误
+func ParallelEach[T any](ctx context.Context, arr []T, limit int, f func(context.Context, T) error) error {
+	return parallelDo(ctx, len(arr), limit, func(ctx context.Context, i int) error {
+		return f(ctx, arr[i])
+	})
+}
+
+// ParallelMap 并发处理数组元素，返回的新数组保持原来的顺序
+// 出现第一个错误或 ctx 被取消时停止，返回 nil 与该错误
+func ParallelMap[T, U any](ctx context.Context, arr []T, limit int, f func(context.Context, T) (U, error)) ([]U, error) {
+	ret := make([]U, len(arr))
+	err := parallelDo(ctx, len(arr), limit, func(ctx context.Context, i int) error {
+		v, err := f(ctx, arr[i])
+		if err != nil {
+			return err
+		}
+		ret[i] = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ParallelFilter 并发过滤数组，f 返回 true 的元素按原来的顺序出现在结果中
+// 出现第一个错误或 ctx 被取消时停止，返回 nil 与该错误
+func ParallelFilter[T any](ctx context.Context, arr []T, limit int, f func(context.Context, T) (bool, error)) ([]T, error) {
+	keep, err := ParallelMap(ctx, arr, limit, f)
+	if err != nil {
+		return nil, err
+	}
+	ret := []T{}
+	for i, v := range arr {
+		if keep[i] {
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// ParallelReduce 以树形的方式并发归约数组，每一轮将相邻的两个元素合并，直到只剩一个元素
+// f 需要满足结合律，合并时保持元素的先后顺序，数组为空时返回 T 的零值
+func ParallelReduce[T any](ctx context.Context, arr []T, limit int, f func(ctx context.Context, a, b T) (T, error)) (T, error) {
+	var zero T
+	if len(arr) == 0 {
+		return zero, ctx.Err()
+	}
+	level := arr
+	for len(level) > 1 {
+		next := make([]T, (len(level)+1)/2)
+		// 奇数个元素时，最后一个元素直接进入下一轮
+		if len(level)%2 == 1 {
+			next[len(next)-1] = level[len(level)-1]
+		}
+		cur := level
+		err := parallelDo(ctx, len(level)/2, limit, func(ctx context.Context, i int) error {
+			v, err := f(ctx, cur[2*i], cur[2*i+1])
+			if err != nil {
+				return err
+			}
+			next[i] = v
+			return nil
+		})
+		if err != nil {
+			return zero, err
+		}
+		level = next
+	}
+	return level[0], ctx.Err()
+}
@@ -0,0 +1,151 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func rangeInts(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = i
+	}
+	return arr
+}
+
+func TestParallelMap(t *testing.T) {
+	ctx := context.Background()
+	arr := rangeInts(1000)
+	got, err := util.ParallelMap(ctx, arr, 8, func(_ context.Context, v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.Map(arr, strconv.Itoa), got)
+
+	got, err = util.ParallelMap(ctx, []int{}, 8, func(_ context.Context, v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, got)
+}
+
+func TestParallelMap_Limit(t *testing.T) {
+	var running, max int32
+	_, err := util.ParallelMap(context.Background(), rangeInts(100), 4, func(_ context.Context, v int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return v, nil
+	})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, max, int32(4))
+}
+
+func TestParallelMap_Error(t *testing.T) {
+	errBad := errors.New("bad")
+	var calls int32
+	got, err := util.ParallelMap(context.Background(), rangeInts(1000), 4, func(_ context.Context, v int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if v == 10 {
+			return 0, errBad
+		}
+		time.Sleep(100 * time.Microsecond)
+		return v, nil
+	})
+	assert.ErrorIs(t, err, errBad)
+	assert.Nil(t, got)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(1000))
+}
+
+func TestParallelEach_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	err := util.ParallelEach(ctx, rangeInts(1000), 4, func(ctx context.Context, v int) error {
+		if atomic.AddInt32(&calls, 1) == 20 {
+			cancel()
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(1000))
+
+	var sum int64
+	err = util.ParallelEach(context.Background(), rangeInts(101), 0, func(_ context.Context, v int) error {
+		atomic.AddInt64(&sum, int64(v))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5050), sum)
+}
+
+func TestParallelFilter(t *testing.T) {
+	got, err := util.ParallelFilter(context.Background(), rangeInts(100), 8, func(_ context.Context, v int) (bool, error) {
+		return v%3 == 0, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, util.Filter(rangeInts(100), func(v int) bool {
+		return v%3 == 0
+	}), got)
+}
+
+func TestParallelReduce(t *testing.T) {
+	ctx := context.Background()
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		got, err := util.ParallelReduce(ctx, rangeInts(n), 4, func(_ context.Context, a, b int) (int, error) {
+			return a + b, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, n*(n-1)/2, got)
+	}
+
+	// 字符串拼接满足结合律但不满足交换律，用来验证顺序
+	words := util.Map(rangeInts(26), func(i int) string {
+		return string(rune('a' + i))
+	})
+	got, err := util.ParallelReduce(ctx, words, 4, func(_ context.Context, a, b string) (string, error) {
+		return a + b, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefghijklmnopqrstuvwxyz", got)
+
+	errBad := errors.New("bad")
+	_, err = util.ParallelReduce(ctx, rangeInts(10), 4, func(_ context.Context, a, b int) (int, error) {
+		return 0, errBad
+	})
+	assert.ErrorIs(t, err, errBad)
+}
+
+func slowSquare(_ context.Context, v int) (int, error) {
+	time.Sleep(50 * time.Microsecond)
+	return v * v, nil
+}
+
+func BenchmarkMap_Sequential(b *testing.B) {
+	arr := rangeInts(200)
+	for i := 0; i < b.N; i++ {
+		util.Map(arr, func(v int) int {
+			r, _ := slowSquare(context.Background(), v)
+			return r
+		})
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	arr := rangeInts(200)
+	for i := 0; i < b.N; i++ {
+		_, _ = util.ParallelMap(context.Background(), arr, 16, slowSquare)
+	}
+}
@@ -0,0 +1,195 @@
+package util
+
+import "golang.org/x/exp/constraints"
+
+// PQItem 优先队列中的元素句柄，可以用于 Update/Fix/Remove
+type PQItem[T any] struct {
+	val T
+	// index 元素在堆中的位置，被移出队列后为 -1
+	index int
+}
+
+// Val 返回句柄对应的值
+func (i *PQItem[T]) Val() T {
+	return i.val
+}
+
+// PriorityQueue 基于二叉堆的优先队列
+// less(a, b) 返回 true 时 a 先出队，所以 a < b 是最小堆，a > b 是最大堆
+type PriorityQueue[T any] struct {
+	items []*PQItem[T]
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue 使用比较函数新建一个优先队列
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewMinPriorityQueue 新建一个最小堆，值小的先出队
+func NewMinPriorityQueue[T constraints.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool {
+		return a < b
+	})
+}
+
+// NewMaxPriorityQueue 新建一个最大堆，值大的先出队
+func NewMaxPriorityQueue[T constraints.Ordered]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b T) bool {
+		return a > b
+	})
+}
+
+// NewPriorityQueueFrom 使用已有的切片建堆，时间复杂度 O(n)
+// 同时返回每个元素的句柄，handles[i] 对应 arr[i]
+func NewPriorityQueueFrom[T any](arr []T, less func(a, b T) bool) (pq *PriorityQueue[T], handles []*PQItem[T]) {
+	pq = NewPriorityQueue(less)
+	handles = make([]*PQItem[T], len(arr))
+	for i, v := range arr {
+		handles[i] = &PQItem[T]{val: v, index: i}
+	}
+	pq.items = append([]*PQItem[T]{}, handles...)
+	for i := len(arr)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return pq, handles
+}
+
+// Push 添加元素，返回元素的句柄
+func (pq *PriorityQueue[T]) Push(v T) *PQItem[T] {
+	item := &PQItem[T]{val: v, index: len(pq.items)}
+	pq.items = append(pq.items, item)
+	pq.up(item.index)
+	return item
+}
+
+// Pop 弹出优先级最高的元素，队列为空时返回 nil
+func (pq *PriorityQueue[T]) Pop() *T {
+	if len(pq.items) == 0 {
+		return nil
+	}
+	item := pq.remove(0)
+	return &item.val
+}
+
+// Peek 返回优先级最高的元素但不弹出，队列为空时返回 nil
+func (pq *PriorityQueue[T]) Peek() *T {
+	if len(pq.items) == 0 {
+		return nil
+	}
+	return &pq.items[0].val
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+func (pq *PriorityQueue[T]) Empty() bool {
+	return pq.Len() == 0
+}
+
+func (pq *PriorityQueue[T]) Clear() {
+	for _, item := range pq.items {
+		item.index = -1
+	}
+	pq.items = nil
+}
+
+// Update 修改句柄对应的值并调整位置，句柄已不在队列中时返回 false
+func (pq *PriorityQueue[T]) Update(item *PQItem[T], v T) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	item.val = v
+	pq.fix(item.index)
+	return true
+}
+
+// Fix 值被外部修改后(例如 T 是指针)，重新调整句柄的位置
+// 句柄已不在队列中时返回 false
+func (pq *PriorityQueue[T]) Fix(item *PQItem[T]) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	pq.fix(item.index)
+	return true
+}
+
+// Remove 移除句柄对应的元素，句柄已不在队列中时返回 false
+func (pq *PriorityQueue[T]) Remove(item *PQItem[T]) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	pq.remove(item.index)
+	return true
+}
+
+// Slice 按堆中的顺序返回所有元素，不保证有序
+func (pq *PriorityQueue[T]) Slice() []T {
+	return Map(pq.items, func(item *PQItem[T]) T {
+		return item.val
+	})
+}
+
+func (pq *PriorityQueue[T]) contains(item *PQItem[T]) bool {
+	return item != nil && item.index >= 0 && item.index < len(pq.items) && pq.items[item.index] == item
+}
+
+func (pq *PriorityQueue[T]) remove(i int) *PQItem[T] {
+	n := len(pq.items) - 1
+	item := pq.items[i]
+	if i != n {
+		pq.swap(i, n)
+	}
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	if i != n {
+		pq.fix(i)
+	}
+	item.index = -1
+	return item
+}
+
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].val, pq.items[parent].val) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down 下沉元素，返回元素是否移动过
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right].val, pq.items[child].val) {
+			child = right
+		}
+		if !pq.less(pq.items[child].val, pq.items[i].val) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
@@ -0,0 +1,118 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func drainPQ[T any](pq *util.PriorityQueue[T]) []T {
+	ret := []T{}
+	for v := pq.Pop(); v != nil; v = pq.Pop() {
+		ret = append(ret, *v)
+	}
+	return ret
+}
+
+func TestNewPriorityQueue(t *testing.T) {
+	pq := util.NewMinPriorityQueue[int]()
+	assert.True(t, pq.Empty())
+	assert.Nil(t, pq.Peek())
+	assert.Nil(t, pq.Pop())
+
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		pq.Push(v)
+	}
+	assert.Equal(t, 5, pq.Len())
+	assert.Equal(t, 1, *pq.Peek())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, drainPQ(pq))
+
+	max := util.NewMaxPriorityQueue[string]()
+	max.Push("b")
+	max.Push("c")
+	max.Push("a")
+	assert.Equal(t, []string{"c", "b", "a"}, drainPQ(max))
+
+	type task struct {
+		name     string
+		priority int
+	}
+	tasks := util.NewPriorityQueue(func(a, b task) bool {
+		return a.priority > b.priority
+	})
+	tasks.Push(task{"low", 1})
+	tasks.Push(task{"high", 9})
+	assert.Equal(t, "high", tasks.Pop().name)
+
+	tasks.Clear()
+	assert.Equal(t, 0, tasks.Len())
+}
+
+func TestPriorityQueue_Handle(t *testing.T) {
+	pq := util.NewMinPriorityQueue[int]()
+	a := pq.Push(10)
+	b := pq.Push(20)
+	c := pq.Push(30)
+	assert.Equal(t, 10, a.Val())
+
+	assert.True(t, pq.Update(c, 5))
+	assert.Equal(t, 5, *pq.Peek())
+
+	assert.True(t, pq.Remove(a))
+	assert.False(t, pq.Remove(a))
+	assert.False(t, pq.Update(a, 1))
+	assert.Equal(t, 2, pq.Len())
+
+	type job struct{ cost int }
+	jobs := util.NewPriorityQueue(func(a, b *job) bool {
+		return a.cost < b.cost
+	})
+	j1 := &job{1}
+	h1 := jobs.Push(j1)
+	jobs.Push(&job{2})
+	j1.cost = 3
+	assert.True(t, jobs.Fix(h1))
+	assert.Equal(t, 2, (*jobs.Pop()).cost)
+
+	assert.Equal(t, 5, *pq.Pop())
+	assert.False(t, pq.Fix(c))
+	assert.Equal(t, []int{20}, drainPQ(pq))
+	assert.False(t, pq.Remove(b))
+}
+
+func TestNewPriorityQueueFrom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = r.Intn(500)
+	}
+	pq, seeded := util.NewPriorityQueueFrom(arr, func(a, b int) bool {
+		return a < b
+	})
+	assert.Equal(t, len(arr), len(seeded))
+	for i, h := range seeded {
+		assert.Equal(t, arr[i], h.Val())
+	}
+	// 初始元素的句柄同样可以更新与移除
+	for _, h := range seeded[:100] {
+		pq.Remove(h)
+	}
+	for _, h := range seeded[100:200] {
+		pq.Update(h, r.Intn(500))
+	}
+	handles := make([]*util.PQItem[int], 0)
+	for i := 0; i < 100; i++ {
+		handles = append(handles, pq.Push(r.Intn(500)))
+	}
+	for _, h := range handles[:50] {
+		pq.Remove(h)
+	}
+	for _, h := range handles[50:] {
+		pq.Update(h, r.Intn(500))
+	}
+	got := drainPQ(pq)
+	assert.Equal(t, 950, len(got))
+	assert.Equal(t, util.Sort(append([]int{}, got...)), got)
+}
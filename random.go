@@ -0,0 +1,143 @@
+package util
+
+import "math/rand"
+
+// 以下带 With 后缀的方法都可以传入 *rand.Rand，使用固定的种子可以得到可复现的结果
+// 传入 nil 时使用 math/rand 的全局随机源
+// 注意 *rand.Rand 不是并发安全的，不能在多个协程中共用
+
+// randSource 随机源，nil 时使用全局随机源
+type randSource struct {
+	r *rand.Rand
+}
+
+func (s randSource) Intn(n int) int {
+	if s.r == nil {
+		return rand.Intn(n)
+	}
+	return s.r.Intn(n)
+}
+
+func (s randSource) Float64() float64 {
+	if s.r == nil {
+		return rand.Float64()
+	}
+	return s.r.Float64()
+}
+
+// RandomWith 同 Random，使用传入的随机源
+func RandomWith[T any](r *rand.Rand, arr []T) *T {
+	if len(arr) == 0 {
+		return nil
+	}
+	return &arr[randSource{r}.Intn(len(arr))]
+}
+
+// ShuffleWith 同 Shuffle，使用传入的随机源
+func ShuffleWith[T any](r *rand.Rand, arr []T) []T {
+	// 洗牌算法
+	if len(arr) == 0 {
+		return arr
+	}
+	src := randSource{r}
+	lastI := len(arr) - 1
+	for lastI > 0 {
+		randI := src.Intn(lastI + 1)
+		arr[randI], arr[lastI] = arr[lastI], arr[randI]
+		lastI--
+	}
+	return arr
+}
+
+// Sample 不放回地随机选取 n 个元素，返回新的数组，n 大于数组长度时返回所有元素(顺序随机)
+// 不会修改原数组
+func Sample[T any](arr []T, n int) []T {
+	return SampleWith(nil, arr, n)
+}
+
+// SampleWith 同 Sample，使用传入的随机源
+func SampleWith[T any](r *rand.Rand, arr []T, n int) []T {
+	if n > len(arr) {
+		n = len(arr)
+	}
+	if n <= 0 {
+		return []T{}
+	}
+	src := randSource{r}
+	// 只对前 n 个位置做洗牌，通过下标映射避免复制整个数组
+	swapped := map[int]int{}
+	at := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+	ret := make([]T, n)
+	for i := 0; i < n; i++ {
+		j := i + src.Intn(len(arr)-i)
+		ret[i] = arr[at(j)]
+		swapped[j] = at(i)
+	}
+	return ret
+}
+
+// WeightedRandom 按照权重随机返回一个元素的指针，权重小于等于 0 的元素不会被选中
+// 数组为空或者所有权重都小于等于 0 时返回 nil
+func WeightedRandom[T any](arr []T, weight func(T) float64) *T {
+	return WeightedRandomWith(nil, arr, weight)
+}
+
+// WeightedRandomWith 同 WeightedRandom，使用传入的随机源
+func WeightedRandomWith[T any](r *rand.Rand, arr []T, weight func(T) float64) *T {
+	weights := make([]float64, len(arr))
+	var total float64
+	for i, v := range arr {
+		if w := weight(v); w > 0 {
+			weights[i] = w
+			total += w
+		}
+	}
+	if total <= 0 {
+		return nil
+	}
+	x := randSource{r}.Float64() * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		last = i
+		if x < w {
+			return &arr[i]
+		}
+		x -= w
+	}
+	// 浮点误差导致没有选中时，返回最后一个有效元素
+	return &arr[last]
+}
+
+// ReservoirSample 蓄水池抽样，从迭代器中等概率地选取 n 个元素
+// 只需要遍历一次，适合元素个数未知或者很大的情况，元素不足 n 个时返回所有元素
+func ReservoirSample[T any](it *Iter[T], n int) []T {
+	return ReservoirSampleWith(nil, it, n)
+}
+
+// ReservoirSampleWith 同 ReservoirSample，使用传入的随机源
+func ReservoirSampleWith[T any](r *rand.Rand, it *Iter[T], n int) []T {
+	if n <= 0 {
+		return []T{}
+	}
+	src := randSource{r}
+	ret := make([]T, 0, n)
+	seen := 0
+	it.Each(func(v T) bool {
+		seen++
+		if len(ret) < n {
+			ret = append(ret, v)
+		} else if j := src.Intn(seen); j < n {
+			ret[j] = v
+		}
+		return true
+	})
+	return ret
+}
@@ -0,0 +1,93 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(42))
+}
+
+func TestRandomWith(t *testing.T) {
+	arr := rangeInts(100)
+	r1, r2 := newRand(), newRand()
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, *util.RandomWith(r1, arr), *util.RandomWith(r2, arr))
+	}
+	assert.Nil(t, util.RandomWith(newRand(), []int{}))
+	assert.Contains(t, arr, *util.RandomWith(nil, arr))
+}
+
+func TestShuffleWith(t *testing.T) {
+	a := util.ShuffleWith(newRand(), rangeInts(20))
+	b := util.ShuffleWith(newRand(), rangeInts(20))
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, rangeInts(20), a)
+	assert.ElementsMatch(t, rangeInts(20), a)
+}
+
+func TestSample(t *testing.T) {
+	arr := rangeInts(100)
+	a := util.SampleWith(newRand(), arr, 10)
+	assert.Equal(t, a, util.SampleWith(newRand(), arr, 10))
+	assert.Len(t, a, 10)
+	assert.Len(t, util.Unique(a), 10)
+	assert.Equal(t, rangeInts(100), arr)
+
+	assert.ElementsMatch(t, []int{1, 2, 3}, util.Sample([]int{1, 2, 3}, 5))
+	assert.Equal(t, []int{}, util.Sample([]int{1, 2, 3}, 0))
+	assert.Equal(t, []int{}, util.Sample([]int{}, 3))
+
+	// 每个元素被选中的概率应该接近 n / len(arr)
+	r := newRand()
+	counts := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		for _, v := range util.SampleWith(r, rangeInts(10), 3) {
+			counts[v]++
+		}
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 3000, c, 200)
+	}
+}
+
+func TestWeightedRandom(t *testing.T) {
+	weight := func(v string) float64 {
+		return map[string]float64{"a": 1, "b": 3, "c": 0}[v]
+	}
+	arr := []string{"a", "b", "c"}
+	r := newRand()
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[*util.WeightedRandomWith(r, arr, weight)]++
+	}
+	assert.Equal(t, 0, counts["c"])
+	assert.InDelta(t, 7500, counts["b"], 250)
+
+	assert.Equal(t, *util.WeightedRandomWith(newRand(), arr, weight), *util.WeightedRandomWith(newRand(), arr, weight))
+	assert.Nil(t, util.WeightedRandom([]string{"c"}, weight))
+	assert.Nil(t, util.WeightedRandom([]string{}, weight))
+}
+
+func TestReservoirSample(t *testing.T) {
+	a := util.ReservoirSampleWith(newRand(), util.IterFromSlice(rangeInts(1000)), 5)
+	b := util.ReservoirSampleWith(newRand(), util.IterFromSlice(rangeInts(1000)), 5)
+	assert.Equal(t, a, b)
+	assert.Len(t, util.Unique(a), 5)
+
+	assert.Equal(t, []int{1, 2}, util.ReservoirSample(util.IterFromSlice([]int{1, 2}), 5))
+	assert.Equal(t, []int{}, util.ReservoirSample(util.IterFromSlice([]int{1, 2}), 0))
+}
+
+func TestSlice_SetRand(t *testing.T) {
+	a := util.NewSlice(rangeInts(20)).SetRand(newRand())
+	b := util.NewSlice(rangeInts(20)).SetRand(newRand())
+	assert.Equal(t, *a.Random(), *b.Random())
+	assert.Equal(t, a.Shuffle().Slice(), b.Shuffle().Slice())
+	assert.Equal(t, a.Sample(5).Slice(), b.Sample(5).Slice())
+	assert.Equal(t, a.Copy().Shuffle().Slice(), b.Copy().Shuffle().Slice())
+}
@@ -0,0 +1,124 @@
+package util
+
+// RingPolicy 环形队列满时的处理策略
+type RingPolicy int
+
+const (
+	// RingReject 队列满时拒绝新元素
+	RingReject RingPolicy = iota
+	// RingOverwrite 队列满时覆盖最早的元素
+	RingOverwrite
+	// RingGrow 队列满时扩容为原来的两倍
+	RingGrow
+)
+
+// RingQueue 基于环形缓冲区的队列，方法与 Queue 一致
+// Push 时不会为每个元素分配节点，适合在热点路径上使用
+type RingQueue[T any] struct {
+	items  []T
+	head   int
+	len    int
+	policy RingPolicy
+}
+
+// NewRingQueue 新建一个容量为 capacity 的环形队列，capacity 小于 1 时按 1 处理
+func NewRingQueue[T any](capacity int, policy RingPolicy) *RingQueue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingQueue[T]{
+		items:  make([]T, capacity),
+		policy: policy,
+	}
+}
+
+// Push 向队列末尾添加元素
+// 使用 RingReject 策略时，队列满了会丢弃新元素，需要知道是否成功可以用 TryPush
+func (r *RingQueue[T]) Push(v T) {
+	r.TryPush(v)
+}
+
+// TryPush 向队列末尾添加元素，只有在 RingReject 策略下队列已满时返回 false
+func (r *RingQueue[T]) TryPush(v T) bool {
+	if r.Full() {
+		switch r.policy {
+		case RingOverwrite:
+			r.items[r.head] = v
+			r.head = (r.head + 1) % len(r.items)
+			return true
+		case RingGrow:
+			r.grow()
+		default:
+			return false
+		}
+	}
+	r.items[(r.head+r.len)%len(r.items)] = v
+	r.len++
+	return true
+}
+
+func (r *RingQueue[T]) Pop() *T {
+	if r.len == 0 {
+		return nil
+	}
+	v := r.items[r.head]
+	var zero T
+	// 释放引用，避免内存泄漏
+	r.items[r.head] = zero
+	r.head = (r.head + 1) % len(r.items)
+	r.len--
+	return &v
+}
+
+func (r *RingQueue[T]) Clear() {
+	var zero T
+	for i := range r.items {
+		r.items[i] = zero
+	}
+	r.head = 0
+	r.len = 0
+}
+
+func (r *RingQueue[T]) Len() int {
+	return r.len
+}
+
+// Cap 返回队列当前的容量
+func (r *RingQueue[T]) Cap() int {
+	return len(r.items)
+}
+
+// Full 队列是否已满
+func (r *RingQueue[T]) Full() bool {
+	return r.len == len(r.items)
+}
+
+func (r *RingQueue[T]) Head() *T {
+	if r.len == 0 {
+		return nil
+	}
+	return &r.items[r.head]
+}
+
+func (r *RingQueue[T]) End() *T {
+	if r.len == 0 {
+		return nil
+	}
+	return &r.items[(r.head+r.len-1)%len(r.items)]
+}
+
+func (r *RingQueue[T]) Each(f func(v T) T) {
+	for i := 0; i < r.len; i++ {
+		k := (r.head + i) % len(r.items)
+		r.items[k] = f(r.items[k])
+	}
+}
+
+// grow 扩容为原来的两倍，并把元素按顺序移动到新缓冲区的开头
+func (r *RingQueue[T]) grow() {
+	items := make([]T, len(r.items)*2)
+	n := copy(items, r.items[r.head:])
+	copy(items[n:], r.items[:r.head])
+	r.items = items
+	r.head = 0
+}
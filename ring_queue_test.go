@@ -0,0 +1,103 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestNewRingQueue(t *testing.T) {
+	q := util.NewRingQueue[int](3, util.RingReject)
+	assert.Equal(t, 0, q.Len())
+	assert.Equal(t, 3, q.Cap())
+	assert.Nil(t, q.Head())
+	assert.Nil(t, q.End())
+	assert.Nil(t, q.Pop())
+
+	q.Push(1)
+	q.Push(2)
+	assert.Equal(t, 2, q.Len())
+	assert.Equal(t, 1, *q.Head())
+	assert.Equal(t, 2, *q.End())
+
+	assert.Equal(t, 1, *q.Pop())
+	q.Push(3)
+	q.Push(4)
+	assert.True(t, q.Full())
+	assert.Equal(t, 2, *q.Head())
+	assert.Equal(t, 4, *q.End())
+
+	q.Each(func(v int) int {
+		return v * v
+	})
+	assert.Equal(t, 4, *q.Pop())
+	assert.Equal(t, 9, *q.Pop())
+	assert.Equal(t, 16, *q.Pop())
+	assert.Nil(t, q.Pop())
+
+	q.Push(5)
+	q.Clear()
+	assert.Equal(t, 0, q.Len())
+	assert.Nil(t, q.Head())
+}
+
+func TestRingQueue_Policy(t *testing.T) {
+	push := func(q *util.RingQueue[int], vs ...int) []bool {
+		var ret []bool
+		for _, v := range vs {
+			ret = append(ret, q.TryPush(v))
+		}
+		return ret
+	}
+	drain := func(q *util.RingQueue[int]) []int {
+		ret := []int{}
+		for v := q.Pop(); v != nil; v = q.Pop() {
+			ret = append(ret, *v)
+		}
+		return ret
+	}
+
+	reject := util.NewRingQueue[int](2, util.RingReject)
+	assert.Equal(t, []bool{true, true, false}, push(reject, 1, 2, 3))
+	assert.Equal(t, []int{1, 2}, drain(reject))
+
+	overwrite := util.NewRingQueue[int](2, util.RingOverwrite)
+	assert.Equal(t, []bool{true, true, true, true}, push(overwrite, 1, 2, 3, 4))
+	assert.Equal(t, 2, overwrite.Len())
+	assert.Equal(t, 3, *overwrite.Head())
+	assert.Equal(t, 4, *overwrite.End())
+	assert.Equal(t, []int{3, 4}, drain(overwrite))
+
+	grow := util.NewRingQueue[int](2, util.RingGrow)
+	push(grow, 1, 2)
+	grow.Pop()
+	// 环绕后再扩容，顺序保持不变
+	assert.Equal(t, []bool{true, true, true}, push(grow, 3, 4, 5))
+	assert.Equal(t, 4, grow.Cap())
+	assert.Equal(t, []int{2, 3, 4, 5}, drain(grow))
+
+	assert.Equal(t, 1, util.NewRingQueue[int](0, util.RingReject).Cap())
+}
+
+func BenchmarkQueue_PushPop(b *testing.B) {
+	q := util.NewQueue[int]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+		if q.Len() > 64 {
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkRingQueue_PushPop(b *testing.B) {
+	q := util.NewRingQueue[int](128, util.RingReject)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+		if q.Len() > 64 {
+			q.Pop()
+		}
+	}
+}
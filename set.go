@@ -0,0 +1,161 @@
+package util
+
+// Set 集合，元素唯一且无序
+// 内部使用 map 保存元素，集合运算不需要每次重新构建 map
+// 零值为空集合，可以直接使用
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewSet 使用传入的元素新建一个集合
+func NewSet[T comparable](vs ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(vs))}
+	return s.Add(vs...)
+}
+
+// NewSetFromSlice 使用 Slice 中的元素新建一个集合
+func NewSetFromSlice[T comparable](s *Slice[T]) *Set[T] {
+	return NewSet(s.Slice()...)
+}
+
+// Add 添加元素
+func (s *Set[T]) Add(vs ...T) *Set[T] {
+	if s.m == nil {
+		s.m = map[T]struct{}{}
+	}
+	for _, v := range vs {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Remove 移除元素，元素不存在时忽略
+func (s *Set[T]) Remove(vs ...T) *Set[T] {
+	for _, v := range vs {
+		delete(s.m, v)
+	}
+	return s
+}
+
+// Has 是否包含元素
+func (s *Set[T]) Has(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len 返回元素个数
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Empty 集合是否为空
+func (s *Set[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+// Clear 清空集合
+func (s *Set[T]) Clear() *Set[T] {
+	s.m = map[T]struct{}{}
+	return s
+}
+
+// Copy 复制集合
+func (s *Set[T]) Copy() *Set[T] {
+	ret := &Set[T]{m: make(map[T]struct{}, s.Len())}
+	for v := range s.m {
+		ret.m[v] = struct{}{}
+	}
+	return ret
+}
+
+// Each 遍历集合中的元素，顺序不固定，f 返回 false 时停止遍历
+func (s *Set[T]) Each(f func(T) bool) {
+	for v := range s.m {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// Union 并集，返回新的集合
+func (s *Set[T]) Union(s2 *Set[T]) *Set[T] {
+	ret := s.Copy()
+	for v := range s2.m {
+		ret.m[v] = struct{}{}
+	}
+	return ret
+}
+
+// Intersection 交集，返回新的集合
+func (s *Set[T]) Intersection(s2 *Set[T]) *Set[T] {
+	small, large := s, s2
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	ret := &Set[T]{m: map[T]struct{}{}}
+	for v := range small.m {
+		if large.Has(v) {
+			ret.m[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Difference 差集, 存在于集合中但是不存在于 s2 中的元素，返回新的集合
+func (s *Set[T]) Difference(s2 *Set[T]) *Set[T] {
+	ret := &Set[T]{m: map[T]struct{}{}}
+	for v := range s.m {
+		if !s2.Has(v) {
+			ret.m[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// SymmetricDifference 对称差集，只存在于其中一个集合中的元素，返回新的集合
+func (s *Set[T]) SymmetricDifference(s2 *Set[T]) *Set[T] {
+	ret := s.Difference(s2)
+	for v := range s2.m {
+		if !s.Has(v) {
+			ret.m[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// IsSubset 集合是否是 s2 的子集
+func (s *Set[T]) IsSubset(s2 *Set[T]) bool {
+	if s.Len() > s2.Len() {
+		return false
+	}
+	for v := range s.m {
+		if !s2.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset 集合是否是 s2 的超集
+func (s *Set[T]) IsSuperset(s2 *Set[T]) bool {
+	return s2.IsSubset(s)
+}
+
+// Equal 两个集合的元素是否完全相同
+func (s *Set[T]) Equal(s2 *Set[T]) bool {
+	return s.Len() == s2.Len() && s.IsSubset(s2)
+}
+
+// Slice 以切片的形式返回集合中的元素，顺序不固定
+func (s *Set[T]) Slice() []T {
+	ret := make([]T, 0, s.Len())
+	for v := range s.m {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// ToSlice 以 Slice 的形式返回集合中的元素，顺序不固定
+func (s *Set[T]) ToSlice() *Slice[T] {
+	return NewSlice(s.Slice())
+}
@@ -0,0 +1,76 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestNewSet(t *testing.T) {
+	s := util.NewSet(1, 2, 2, 3)
+	assert.Equal(t, 3, s.Len())
+	assert.True(t, s.Has(1))
+	assert.False(t, s.Has(4))
+
+	s.Add(4).Remove(1, 5)
+	assert.ElementsMatch(t, []int{2, 3, 4}, s.Slice())
+
+	c := s.Copy()
+	c.Add(10)
+	assert.False(t, s.Has(10))
+
+	var sum int
+	s.Each(func(v int) bool {
+		sum += v
+		return true
+	})
+	assert.Equal(t, 9, sum)
+
+	s.Clear()
+	assert.True(t, s.Empty())
+	assert.Equal(t, []int{}, s.Slice())
+}
+
+func TestSet_ZeroValue(t *testing.T) {
+	var s util.Set[int]
+	assert.True(t, s.Empty())
+	assert.False(t, s.Has(1))
+	s.Remove(1)
+	assert.True(t, s.Union(util.NewSet(2)).Equal(util.NewSet(2)))
+	s.Add(1, 1)
+	assert.True(t, s.Has(1))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestSet_Operations(t *testing.T) {
+	a := util.NewSet(1, 2, 3, 4)
+	b := util.NewSet(3, 4, 5)
+
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, a.Union(b).Slice())
+	assert.ElementsMatch(t, []int{3, 4}, a.Intersection(b).Slice())
+	assert.ElementsMatch(t, []int{3, 4}, b.Intersection(a).Slice())
+	assert.ElementsMatch(t, []int{1, 2}, a.Difference(b).Slice())
+	assert.ElementsMatch(t, []int{5}, b.Difference(a).Slice())
+	assert.ElementsMatch(t, []int{1, 2, 5}, a.SymmetricDifference(b).Slice())
+
+	// 运算不会修改原集合
+	assert.Equal(t, 4, a.Len())
+	assert.Equal(t, 3, b.Len())
+
+	sub := util.NewSet(1, 2)
+	assert.True(t, sub.IsSubset(a))
+	assert.False(t, a.IsSubset(sub))
+	assert.True(t, a.IsSuperset(sub))
+	assert.False(t, b.IsSuperset(sub))
+	assert.True(t, util.NewSet[int]().IsSubset(a))
+	assert.True(t, a.Equal(util.NewSet(4, 3, 2, 1)))
+	assert.False(t, a.Equal(b))
+}
+
+func TestSet_Slice(t *testing.T) {
+	s := util.NewSlice([]string{"a", "b", "a"}).ToSet()
+	assert.Equal(t, 2, s.Len())
+	assert.ElementsMatch(t, []string{"a", "b"}, s.ToSlice().Slice())
+	assert.True(t, util.NewSetFromSlice(util.NewSlice([]string{"b"})).IsSubset(s))
+}
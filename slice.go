@@ -3,16 +3,23 @@ package util
 import (
 	"bytes"
 	"encoding/json"
+	"math/rand"
 )
 
 // Slice 提供一系列的切片操作, 大部分操作是原地操作，返回原切片，可以用于链式操作
 type Slice[T comparable] struct {
 	slice []T
+	// rand 随机相关方法使用的随机源，nil 时使用 math/rand 的全局随机源
+	rand *rand.Rand
+	// sqlEncoding Value 与 Scan 使用的格式
+	sqlEncoding SQLEncoding
+	// err 链式调用中 ...Err 方法产生的错误，出现错误后后续的 ...Err 方法不会再执行
+	err error
 }
 
 // NewSlice 新建一个集合
 func NewSlice[U comparable](vs []U) *Slice[U] {
-	return &Slice[U]{vs}
+	return &Slice[U]{slice: vs}
 }
 
 // Set 设置集合中的数据，会覆盖原有数据
@@ -88,11 +95,11 @@ func (s *Slice[T]) Index(i int) *T {
 	return &s.Slice()[i]
 }
 
-// Copy 复制集合
+// Copy 复制集合，会保留设置的随机源与数据库格式
 func (s *Slice[T]) Copy() *Slice[T] {
 	dst := make([]T, s.Len())
 	copy(dst, s.Slice())
-	return NewSlice(dst)
+	return NewSlice(dst).SetRand(s.rand).SetSQLEncoding(s.sqlEncoding)
 }
 
 // Merge 将传入的集合组合并到集合中
@@ -114,21 +121,34 @@ func (s *Slice[T]) Reverse() *Slice[T] {
 	return s
 }
 
+// SetRand 设置 Random、Shuffle、Sample 使用的随机源
+// 使用固定种子的 rand 对象可以得到可复现的结果，传入 nil 时使用 math/rand 的全局随机源
+func (s *Slice[T]) SetRand(r *rand.Rand) *Slice[T] {
+	s.rand = r
+	return s
+}
+
 // Random 随机返回一个元素的指针
-// 使用初始化时创建的 rand 对象
+// 使用 SetRand 设置的 rand 对象
 // 集合为空时，返回 nil
 func (s *Slice[T]) Random() *T {
-	return Random(s.Slice())
+	return RandomWith(s.rand, s.Slice())
 }
 
 // Shuffle 打乱集合的顺序
-// 使用初始化时设置的 rand 对象
+// 使用 SetRand 设置的 rand 对象
 // 使用洗牌算法，原顺序是有概率出现的
 func (s *Slice[T]) Shuffle() *Slice[T] {
-	s.Set(Shuffle(s.Slice()))
+	s.Set(ShuffleWith(s.rand, s.Slice()))
 	return s
 }
 
+// Sample 不放回地随机选取 n 个元素，以新的集合返回
+// 使用 SetRand 设置的 rand 对象
+func (s *Slice[T]) Sample(n int) *Slice[T] {
+	return NewSlice(SampleWith(s.rand, s.Slice(), n)).SetRand(s.rand)
+}
+
 // Contains 返回集合中是否存在指定的元素
 func (s *Slice[T]) Contains(v T) bool {
 	return Contains(s.Slice(), v)
@@ -168,9 +188,43 @@ func (s *Slice[T]) Equal(s2 *Slice[T]) bool {
 	return Equal(s.Slice(), s2.Slice())
 }
 
-// JSON :json.Marshal 处理集合元素
+// NewSliceFromJSON 使用 json 数组新建一个集合，null 会得到空集合
+func NewSliceFromJSON[T comparable](data []byte) (*Slice[T], error) {
+	s := NewSlice([]T{})
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// JSON :json.Marshal 处理集合元素，集合为空时总是返回 []
 func (s *Slice[T]) JSON() ([]byte, error) {
-	return json.Marshal(s.Slice())
+	return s.MarshalJSON()
+}
+
+// MarshalJSON 实现 json.Marshaler，集合作为 json 数组编码
+// 集合为空时总是编码为 []，不会编码为 null
+func (s *Slice[T]) MarshalJSON() ([]byte, error) {
+	if s.slice == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.slice)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，会覆盖集合中原有的数据
+// null 与 [] 都会得到空集合，与函数方式返回 []T{} 的约定一致
+// 注意 *Slice 类型的字段遇到 null 时，encoding/json 会直接把字段设置为 nil
+func (s *Slice[T]) UnmarshalJSON(data []byte) error {
+	var vs []T
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	if vs == nil {
+		vs = []T{}
+	}
+	// 不使用 Set，避免 Reset 影响原来的底层数组
+	s.slice = vs
+	return nil
 }
 
 // JSONString 同 JSON, 但是结果会作为 string 返回
@@ -182,13 +236,17 @@ func (s *Slice[T]) JSONString() (string, error) {
 	return string(b), nil
 }
 
-// Pretty 调试方法，返回美化的 json 字符串
+// Pretty 调试方法，返回美化的 json 字符串，与 MarshalJSON 一致，集合为空时为 []
 func (s *Slice[T]) Pretty() string {
 	bf := bytes.NewBuffer([]byte{})
 	jsonEncoder := json.NewEncoder(bf)
 	jsonEncoder.SetEscapeHTML(false)
 	jsonEncoder.SetIndent("", "\t")
-	_ = jsonEncoder.Encode(s.Slice())
+	vs := s.slice
+	if vs == nil {
+		vs = []T{}
+	}
+	_ = jsonEncoder.Encode(vs)
 	return bf.String()
 }
 
@@ -198,9 +256,164 @@ func (s *Slice[T]) Diff(c2 *Slice[T]) *Slice[T] {
 	return NewSlice(Diff(s.Slice(), c2.Slice()))
 }
 
+// SortBy 使用传入的比较方法对集合原地排序
+func (s *Slice[T]) SortBy(less func(a, b T) bool) *Slice[T] {
+	SortBy(s.Slice(), less)
+	return s
+}
+
+// SortStable 同 SortBy，但是相等的元素会保持原来的顺序
+func (s *Slice[T]) SortStable(less func(a, b T) bool) *Slice[T] {
+	SortStableBy(s.Slice(), less)
+	return s
+}
+
+// Partition 按照 f 的返回值将集合分成两个新的集合，f 返回 true 的在前一个
+func (s *Slice[T]) Partition(f func(T) bool) (*Slice[T], *Slice[T]) {
+	yes, no := Partition(s.Slice(), f)
+	return NewSlice(yes), NewSlice(no)
+}
+
+// Chunk 每 size 个元素分为一组，返回的集合与原集合共享底层数组
+func (s *Slice[T]) Chunk(size int) []*Slice[T] {
+	return Map(Chunk(s.Slice(), size), NewSlice[T])
+}
+
+// Windows 滑动窗口，返回的集合与原集合共享底层数组
+func (s *Slice[T]) Windows(size, step int) []*Slice[T] {
+	return Map(Windows(s.Slice(), size, step), NewSlice[T])
+}
+
+// Reduce 从初始值 init 开始遍历集合，返回一个值
+// 方法不能再定义新的泛型，结果类型与元素类型不一致时可以使用 Fold() 方法
+func (s *Slice[T]) Reduce(init T, f func(acc T, v T) T) T {
+	return Fold(s.Slice(), init, f)
+}
+
+// Accumulate 同 Reduce，但是以新的集合返回每一步的结果，见 Scan()
+func (s *Slice[T]) Accumulate(init T, f func(acc T, v T) T) *Slice[T] {
+	return NewSlice(Scan(s.Slice(), init, f))
+}
+
+// Err 返回链式调用中 ...Err 方法产生的错误
+func (s *Slice[T]) Err() error {
+	return s.err
+}
+
+// EachErr 同 Each，f 可以返回错误，出错的元素保持不变
+// 集合已经有错误时不会执行
+func (s *Slice[T]) EachErr(f func(T) (T, error), mode ...ErrMode) *Slice[T] {
+	if s.err != nil {
+		return s
+	}
+	c := newErrCollector(mode)
+	for k, v := range s.slice {
+		nv, err := f(v)
+		if err != nil {
+			if c.add(err) {
+				break
+			}
+			continue
+		}
+		s.slice[k] = nv
+	}
+	s.err = c.err()
+	return s
+}
+
+// FilterErr 同 Filter，f 可以返回错误，错误通过 Err 获取
+// 集合已经有错误时不会执行，出现错误时集合元素保持不变
+func (s *Slice[T]) FilterErr(f func(T) (bool, error), mode ...ErrMode) *Slice[T] {
+	if s.err != nil {
+		return s
+	}
+	ret, err := FilterErr(s.Slice(), f, mode...)
+	if err != nil {
+		s.err = err
+		return s
+	}
+	return s.Set(ret)
+}
+
+// MapErr 同 Map，f 可以返回错误，返回新的集合，错误会传递到新的集合中
+// 集合已经有错误时不会执行，返回空集合
+func (s *Slice[T]) MapErr(f func(T) (T, error), mode ...ErrMode) *Slice[T] {
+	if s.err != nil {
+		return &Slice[T]{slice: []T{}, err: s.err}
+	}
+	ret, err := MapErr(s.Slice(), f, mode...)
+	if err != nil {
+		return &Slice[T]{slice: []T{}, err: err}
+	}
+	return NewSlice(ret)
+}
+
+// ToSet 将集合中的元素转换为 Set，重复的元素只会保留一个
+func (s *Slice[T]) ToSet() *Set[T] {
+	return NewSetFromSlice(s)
+}
+
+// Intersect 返回同时存在于集合与 c2 中的元素，以新的集合返回
+func (s *Slice[T]) Intersect(c2 *Slice[T]) *Slice[T] {
+	return NewSlice(Intersect(s.Slice(), c2.Slice()))
+}
+
+// Union 返回集合与传入集合的并集，以新的集合返回
+func (s *Slice[T]) Union(ss ...*Slice[T]) *Slice[T] {
+	return NewSlice(Union(append([][]T{s.Slice()}, Map(ss, (*Slice[T]).Slice)...)...))
+}
+
+// SymmetricDiff 返回只存在于集合或 c2 其中之一的元素，以新的集合返回
+func (s *Slice[T]) SymmetricDiff(c2 *Slice[T]) *Slice[T] {
+	return NewSlice(SymmetricDiff(s.Slice(), c2.Slice()))
+}
+
 // Map 遍历集合的元素，并使用传入的方法处理元素
 // 返回新的集合
 // 方法不能再定义新的泛型，所以响应值只能元素类型，需要响应与输入类型不一致的可以直接用 Map() 方法
 func (s *Slice[T]) Map(f func(T) T) *Slice[T] {
 	return NewSlice(Map(s.Slice(), f))
 }
+
+// MapSlice 遍历集合的元素，并使用传入的方法处理元素，可以改变元素的类型
+// 返回新的集合
+func MapSlice[T, U comparable](s *Slice[T], f func(T) U) *Slice[U] {
+	return NewSlice(Map(s.Slice(), f))
+}
+
+// FlatMapSlice 每个元素映射为一个切片，并按顺序展开为新的集合
+func FlatMapSlice[T, U comparable](s *Slice[T], f func(T) []U) *Slice[U] {
+	return NewSlice(Merge([]U{}, Map(s.Slice(), f)...))
+}
+
+// GroupBySlice 按照 key 方法的返回值对集合元素分组，每组内保持原来的顺序
+func GroupBySlice[T comparable, K comparable](s *Slice[T], key func(T) K) map[K]*Slice[T] {
+	ret := map[K]*Slice[T]{}
+	for k, vs := range GroupBy(s.Slice(), key) {
+		ret[k] = NewSlice(vs)
+	}
+	return ret
+}
+
+// KeyBySlice 按照 key 方法的返回值索引集合元素，key 重复时后面的元素会覆盖前面的
+func KeyBySlice[T comparable, K comparable](s *Slice[T], key func(T) K) map[K]T {
+	return KeyBy(s.Slice(), key)
+}
+
+// CountBySlice 按照 key 方法的返回值统计集合元素个数
+func CountBySlice[T comparable, K comparable](s *Slice[T], key func(T) K) map[K]int {
+	return CountBy(s.Slice(), key)
+}
+
+// ZipSlices 将两个集合的元素按顺序组合，长度以较短的集合为准
+func ZipSlices[A, B comparable](a *Slice[A], b *Slice[B]) *Slice[Pair[A, B]] {
+	n := a.Len()
+	if b.Len() < n {
+		n = b.Len()
+	}
+	ret := make([]Pair[A, B], n)
+	for i := range ret {
+		ret[i] = Pair[A, B]{a.Slice()[i], b.Slice()[i]}
+	}
+	return NewSlice(ret)
+}
@@ -1,7 +1,11 @@
 package util_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
 	"testing"
 
 	util "github.com/zhan3333/goutil"
@@ -817,3 +821,221 @@ func TestSlice_Unique(t *testing.T) {
 func PInt(i int) *int {
 	return &i
 }
+
+func TestSlice_SortBy(t *testing.T) {
+	s := util.NewSlice([]int{3, 1, 2})
+	assert.Equal(t, []int{3, 2, 1}, s.SortBy(func(a, b int) bool {
+		return a > b
+	}).Slice())
+
+	type pair struct {
+		k string
+		v int
+	}
+	ps := util.NewSlice([]pair{{"a", 2}, {"b", 1}, {"c", 2}, {"d", 1}})
+	assert.Equal(t, []pair{{"b", 1}, {"d", 1}, {"a", 2}, {"c", 2}}, ps.SortStable(func(a, b pair) bool {
+		return a.v < b.v
+	}).Slice())
+}
+
+func TestMapSlice(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3})
+	assert.Equal(t, []string{"1", "2", "3"}, util.MapSlice(s, strconv.Itoa).Slice())
+	assert.Equal(t, []string{"2", "4"}, util.MapSlice(s.Copy().Filter(func(i int) bool {
+		return i < 3
+	}), func(i int) string {
+		return strconv.Itoa(i * 2)
+	}).Slice())
+	assert.Equal(t, []string{}, util.MapSlice(util.NewSlice([]int{}), strconv.Itoa).Slice())
+}
+
+func TestFlatMapSlice(t *testing.T) {
+	s := util.NewSlice([]string{"a,b", "", "c"})
+	assert.Equal(t, []string{"a", "b", "c"}, util.FlatMapSlice(s, func(v string) []string {
+		if v == "" {
+			return nil
+		}
+		return strings.Split(v, ",")
+	}).Slice())
+	assert.Equal(t, []int{}, util.FlatMapSlice(util.NewSlice([]int{}), func(v int) []int {
+		return []int{v}
+	}).Slice())
+}
+
+func TestGroupBySlice(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3, 4, 5})
+	groups := util.GroupBySlice(s, func(i int) bool {
+		return i%2 == 0
+	})
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []int{2, 4}, groups[true].Slice())
+	assert.Equal(t, []int{1, 3, 5}, groups[false].Slice())
+}
+
+func TestKeyBySlice(t *testing.T) {
+	s := util.NewSlice([]string{"a", "bb", "cc"})
+	assert.Equal(t, map[int]string{1: "a", 2: "cc"}, util.KeyBySlice(s, func(v string) int {
+		return len(v)
+	}))
+}
+
+func TestZipSlices(t *testing.T) {
+	a := util.NewSlice([]int{1, 2, 3})
+	b := util.NewSlice([]string{"a", "b"})
+	assert.Equal(t, []util.Pair[int, string]{{1, "a"}, {2, "b"}}, util.ZipSlices(a, b).Slice())
+	assert.Equal(t, []util.Pair[int, string]{}, util.ZipSlices(a, util.NewSlice([]string{})).Slice())
+}
+
+func TestCountBySlice(t *testing.T) {
+	s := util.NewSlice([]string{"a", "bb", "cc"})
+	assert.Equal(t, map[int]int{1: 1, 2: 2}, util.CountBySlice(s, func(v string) int {
+		return len(v)
+	}))
+}
+
+func TestSlice_Partition(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3, 4})
+	even, odd := s.Partition(func(i int) bool {
+		return i%2 == 0
+	})
+	assert.Equal(t, []int{2, 4}, even.Slice())
+	assert.Equal(t, []int{1, 3}, odd.Slice())
+	assert.Equal(t, []int{1, 2, 3, 4}, s.Slice())
+}
+
+func TestSlice_Chunk(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3, 4, 5})
+	chunks := s.Chunk(2)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, util.Map(chunks, (*util.Slice[int]).Slice))
+	assert.Equal(t, []int{3, 7, 5}, util.Map(chunks, func(c *util.Slice[int]) int {
+		return util.Sum(c.Slice(), func(i int) int {
+			return i
+		})
+	}))
+}
+
+func TestSlice_Windows(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3, 4})
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}}, util.Map(s.Windows(3, 1), (*util.Slice[int]).Slice))
+	assert.Equal(t, 0, len(s.Windows(5, 1)))
+}
+
+func TestSlice_SetAlgebra(t *testing.T) {
+	a := util.NewSlice([]int{1, 2, 3, 2})
+	b := util.NewSlice([]int{3, 4, 2})
+	assert.Equal(t, []int{2, 3}, a.Intersect(b).Slice())
+	assert.Equal(t, []int{1, 2, 3, 4}, a.Union(b).Slice())
+	assert.Equal(t, []int{1, 2, 3, 5}, a.Union(util.NewSlice([]int{5})).Slice())
+	assert.Equal(t, []int{1, 4}, a.SymmetricDiff(b).Slice())
+	assert.Equal(t, []int{1, 2, 3, 2}, a.Slice())
+}
+
+func TestSlice_Err(t *testing.T) {
+	nonNegative := func(i int) (bool, error) {
+		if i < 0 {
+			return false, fmt.Errorf("negative %d", i)
+		}
+		return i > 0, nil
+	}
+	half := func(i int) (int, error) {
+		if i%2 != 0 {
+			return 0, fmt.Errorf("odd %d", i)
+		}
+		return i / 2, nil
+	}
+
+	s := util.NewSlice([]int{0, 2, 4}).FilterErr(nonNegative).MapErr(half)
+	assert.NoError(t, s.Err())
+	assert.Equal(t, []int{1, 2}, s.Slice())
+
+	s = util.NewSlice([]int{2, -1, 4}).FilterErr(nonNegative).MapErr(half)
+	assert.EqualError(t, s.Err(), "negative -1")
+	assert.Equal(t, []int{}, s.Slice())
+
+	s = util.NewSlice([]int{1, 2, 3}).FilterErr(nonNegative).MapErr(half, util.CollectErrors)
+	assert.EqualError(t, s.Err(), "odd 1\nodd 3")
+
+	s = util.NewSlice([]int{2, 3, 4}).EachErr(func(i int) (int, error) {
+		v, err := half(i)
+		return v, err
+	}, util.CollectErrors)
+	assert.EqualError(t, s.Err(), "odd 3")
+	assert.Equal(t, []int{1, 3, 2}, s.Slice())
+
+	// 已经有错误时，后续的 ...Err 方法不会执行
+	var calls int
+	s.EachErr(func(i int) (int, error) {
+		calls++
+		return i, nil
+	})
+	assert.Equal(t, 0, calls)
+}
+
+func TestSlice_Reduce(t *testing.T) {
+	s := util.NewSlice([]int{1, 2, 3, 4})
+	assert.Equal(t, 24, s.Reduce(1, func(acc, v int) int {
+		return acc * v
+	}))
+	assert.Equal(t, []int{1, 3, 6, 10}, s.Accumulate(0, func(acc, v int) int {
+		return acc + v
+	}).Slice())
+	assert.Equal(t, 7, util.NewSlice([]int{}).Reduce(7, func(acc, v int) int {
+		return acc + v
+	}))
+}
+
+func TestSlice_JSON(t *testing.T) {
+	b, err := util.NewSlice([]int{1, 2}).JSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", string(b))
+
+	b, err = util.NewSlice[int](nil).JSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	s, err := util.NewSliceFromJSON[string]([]byte(`["a","b"]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, s.Slice())
+
+	for _, data := range []string{"null", "[]"} {
+		s, err = util.NewSliceFromJSON[string]([]byte(data))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{}, s.Slice())
+	}
+
+	_, err = util.NewSliceFromJSON[int]([]byte(`["a"]`))
+	assert.Error(t, err)
+}
+
+func TestSlice_JSONRoundTrip(t *testing.T) {
+	type request struct {
+		IDs  *util.Slice[int]    `json:"ids"`
+		Tags *util.Slice[string] `json:"tags"`
+		Opt  *util.Slice[int]    `json:"opt,omitempty"`
+	}
+	in := request{
+		IDs:  util.NewSlice([]int{1, 2, 3}),
+		Tags: util.NewSlice([]string{}),
+	}
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ids":[1,2,3],"tags":[]}`, string(b))
+
+	var out request
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.True(t, in.IDs.Equal(out.IDs))
+	assert.Equal(t, []string{}, out.Tags.Slice())
+	assert.Nil(t, out.Opt)
+
+	// 解码会覆盖原有的数据，不会修改原来的底层数组
+	arr := []int{9, 9}
+	s := util.NewSlice(arr)
+	assert.NoError(t, json.Unmarshal([]byte("[1]"), s))
+	assert.Equal(t, []int{1}, s.Slice())
+	assert.Equal(t, []int{9, 9}, arr)
+}
+
+func TestSlice_PrettyNil(t *testing.T) {
+	assert.Equal(t, "[]\n", util.NewSlice[int](nil).Pretty())
+	assert.Equal(t, "[\n\t\"<a>\"\n]\n", util.NewSlice([]string{"<a>"}).Pretty())
+}
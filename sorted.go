@@ -0,0 +1,234 @@
+package util
+
+import "golang.org/x/exp/constraints"
+
+// 以下方法都要求传入的数组已经按照从小到大排好序(可以先调用 Sort)
+// 带 By 后缀的方法使用传入的比较方法，要求数组按照同一个比较方法排好序
+
+// LowerBound 返回第一个大于等于 v 的元素的下标，不存在时返回 len(arr)
+func LowerBound[T constraints.Ordered](arr []T, v T) int {
+	return LowerBoundBy(arr, v, lessOrdered[T])
+}
+
+// LowerBoundBy 同 LowerBound，使用传入的比较方法
+func LowerBoundBy[T any](arr []T, v T, less func(a, b T) bool) int {
+	lo, hi := 0, len(arr)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(arr[mid], v) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound 返回第一个大于 v 的元素的下标，不存在时返回 len(arr)
+func UpperBound[T constraints.Ordered](arr []T, v T) int {
+	return UpperBoundBy(arr, v, lessOrdered[T])
+}
+
+// UpperBoundBy 同 UpperBound，使用传入的比较方法
+func UpperBoundBy[T any](arr []T, v T, less func(a, b T) bool) int {
+	lo, hi := 0, len(arr)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(v, arr[mid]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// BinarySearch 二分查找，返回 v 第一次出现的下标与是否存在
+// 不存在时返回 v 应该插入的位置
+func BinarySearch[T constraints.Ordered](arr []T, v T) (int, bool) {
+	return BinarySearchBy(arr, v, lessOrdered[T])
+}
+
+// BinarySearchBy 同 BinarySearch，使用传入的比较方法，!less(a, b) && !less(b, a) 时认为相等
+func BinarySearchBy[T any](arr []T, v T, less func(a, b T) bool) (int, bool) {
+	i := LowerBoundBy(arr, v, less)
+	return i, i < len(arr) && !less(v, arr[i])
+}
+
+// InsertSorted 插入元素并保持有序，相等的元素插入在已有元素的后面
+func InsertSorted[T constraints.Ordered](arr []T, vs ...T) []T {
+	return InsertSortedBy(arr, lessOrdered[T], vs...)
+}
+
+// InsertSortedBy 同 InsertSorted，使用传入的比较方法
+func InsertSortedBy[T any](arr []T, less func(a, b T) bool, vs ...T) []T {
+	for _, v := range vs {
+		i := UpperBoundBy(arr, v, less)
+		var zero T
+		arr = append(arr, zero)
+		copy(arr[i+1:], arr[i:])
+		arr[i] = v
+	}
+	return arr
+}
+
+// RemoveSorted 移除第一个等于 v 的元素并保持有序，返回 v 是否存在
+func RemoveSorted[T constraints.Ordered](arr []T, v T) ([]T, bool) {
+	return RemoveSortedBy(arr, v, lessOrdered[T])
+}
+
+// RemoveSortedBy 同 RemoveSorted，使用传入的比较方法
+func RemoveSortedBy[T any](arr []T, v T, less func(a, b T) bool) ([]T, bool) {
+	i, ok := BinarySearchBy(arr, v, less)
+	if !ok {
+		return arr, false
+	}
+	copy(arr[i:], arr[i+1:])
+	var zero T
+	arr[len(arr)-1] = zero
+	return arr[:len(arr)-1], true
+}
+
+// IsSorted 数组是否已经从小到大排好序
+func IsSorted[T constraints.Ordered](arr []T) bool {
+	return IsSortedBy(arr, lessOrdered[T])
+}
+
+// IsSortedBy 同 IsSorted，使用传入的比较方法
+func IsSortedBy[T any](arr []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(arr); i++ {
+		if less(arr[i], arr[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MergeSorted 多路归并多个有序数组，返回新的有序数组
+// 相等的元素按照传入数组的先后顺序排列
+func MergeSorted[T constraints.Ordered](arrs ...[]T) []T {
+	return MergeSortedBy(lessOrdered[T], arrs...)
+}
+
+// MergeSortedBy 同 MergeSorted，使用传入的比较方法
+func MergeSortedBy[T any](less func(a, b T) bool, arrs ...[]T) []T {
+	var total int
+	for _, arr := range arrs {
+		total += len(arr)
+	}
+	ret := make([]T, 0, total)
+
+	// cursor 记录每个数组当前的位置
+	type cursor struct {
+		arr   int
+		index int
+	}
+	pq := NewPriorityQueue(func(a, b cursor) bool {
+		va, vb := arrs[a.arr][a.index], arrs[b.arr][b.index]
+		if less(va, vb) {
+			return true
+		}
+		if less(vb, va) {
+			return false
+		}
+		return a.arr < b.arr
+	})
+	for i, arr := range arrs {
+		if len(arr) > 0 {
+			pq.Push(cursor{arr: i})
+		}
+	}
+	for !pq.Empty() {
+		c := *pq.Pop()
+		ret = append(ret, arrs[c.arr][c.index])
+		if c.index+1 < len(arrs[c.arr]) {
+			pq.Push(cursor{arr: c.arr, index: c.index + 1})
+		}
+	}
+	return ret
+}
+
+func lessOrdered[T constraints.Ordered](a, b T) bool {
+	return a < b
+}
+
+// SortedSlice 始终保持有序的切片，插入与删除后仍然有序
+type SortedSlice[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewSortedSlice 使用传入的元素新建一个从小到大排序的切片，不会修改传入的数组
+func NewSortedSlice[T constraints.Ordered](vs []T) *SortedSlice[T] {
+	return NewSortedSliceBy(vs, lessOrdered[T])
+}
+
+// NewSortedSliceBy 同 NewSortedSlice，使用传入的比较方法排序
+func NewSortedSliceBy[T any](vs []T, less func(a, b T) bool) *SortedSlice[T] {
+	items := SortStableBy(append([]T{}, vs...), less)
+	return &SortedSlice[T]{items: items, less: less}
+}
+
+// Insert 插入元素，相等的元素插入在已有元素的后面
+func (s *SortedSlice[T]) Insert(vs ...T) *SortedSlice[T] {
+	s.items = InsertSortedBy(s.items, s.less, vs...)
+	return s
+}
+
+// Remove 移除第一个与 v 相等的元素，返回 v 是否存在
+func (s *SortedSlice[T]) Remove(v T) bool {
+	var ok bool
+	s.items, ok = RemoveSortedBy(s.items, v, s.less)
+	return ok
+}
+
+// Search 返回 v 第一次出现的下标，不存在时返回 -1
+func (s *SortedSlice[T]) Search(v T) int {
+	i, ok := BinarySearchBy(s.items, v, s.less)
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// Contains 是否包含 v
+func (s *SortedSlice[T]) Contains(v T) bool {
+	return s.Search(v) >= 0
+}
+
+// Index 返回 i 下标对应的元素，下标不存在时返回 nil
+func (s *SortedSlice[T]) Index(i int) *T {
+	if i < 0 || i >= len(s.items) {
+		return nil
+	}
+	return &s.items[i]
+}
+
+// First 返回最小的元素，为空时返回 nil
+func (s *SortedSlice[T]) First() *T {
+	return First(s.items)
+}
+
+// Last 返回最大的元素，为空时返回 nil
+func (s *SortedSlice[T]) Last() *T {
+	return Last(s.items)
+}
+
+// Range 返回 [lo, hi) 范围内的元素，与内部数据共享底层数组
+func (s *SortedSlice[T]) Range(lo, hi T) []T {
+	i := LowerBoundBy(s.items, lo, s.less)
+	j := LowerBoundBy(s.items, hi, s.less)
+	if j < i {
+		j = i
+	}
+	return s.items[i:j:j]
+}
+
+func (s *SortedSlice[T]) Len() int {
+	return len(s.items)
+}
+
+// Slice 返回有序的元素，修改返回的切片会破坏有序性
+func (s *SortedSlice[T]) Slice() []T {
+	return s.items
+}
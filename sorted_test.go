@@ -0,0 +1,125 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestBinarySearch(t *testing.T) {
+	arr := []int{1, 3, 3, 3, 5, 7}
+	i, ok := util.BinarySearch(arr, 3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, i)
+	i, ok = util.BinarySearch(arr, 4)
+	assert.False(t, ok)
+	assert.Equal(t, 4, i)
+	i, ok = util.BinarySearch([]int{}, 4)
+	assert.False(t, ok)
+	assert.Equal(t, 0, i)
+
+	assert.Equal(t, 1, util.LowerBound(arr, 3))
+	assert.Equal(t, 4, util.UpperBound(arr, 3))
+	assert.Equal(t, 0, util.LowerBound(arr, 0))
+	assert.Equal(t, 6, util.UpperBound(arr, 7))
+
+	byAge := func(a, b testUser) bool {
+		return a.Age < b.Age
+	}
+	users := util.SortStableBy(testUsers(), byAge)
+	i, ok = util.BinarySearchBy(users, testUser{Age: 20}, byAge)
+	assert.True(t, ok)
+	assert.Equal(t, "c", users[i].Name)
+	assert.Equal(t, 3, util.UpperBoundBy(users, testUser{Age: 20}, byAge))
+	assert.Equal(t, 1, util.LowerBoundBy(users, testUser{Age: 20}, byAge))
+}
+
+func TestInsertRemoveSorted(t *testing.T) {
+	arr := util.InsertSorted([]int{}, 5, 1, 3, 3, 9)
+	assert.Equal(t, []int{1, 3, 3, 5, 9}, arr)
+
+	arr, ok := util.RemoveSorted(arr, 3)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 3, 5, 9}, arr)
+	arr, ok = util.RemoveSorted(arr, 4)
+	assert.False(t, ok)
+	assert.Equal(t, []int{1, 3, 5, 9}, arr)
+
+	byAge := func(a, b testUser) bool {
+		return a.Age < b.Age
+	}
+	users := util.InsertSortedBy([]testUser{}, byAge, testUsers()...)
+	assert.Equal(t, []testUser{{"d", 10}, {"c", 20}, {"b", 20}, {"a", 30}}, users)
+	users, ok = util.RemoveSortedBy(users, testUser{Age: 20}, byAge)
+	assert.True(t, ok)
+	assert.Equal(t, []testUser{{"d", 10}, {"b", 20}, {"a", 30}}, users)
+}
+
+func TestIsSorted(t *testing.T) {
+	assert.True(t, util.IsSorted([]int{}))
+	assert.True(t, util.IsSorted([]int{1, 1, 2}))
+	assert.False(t, util.IsSorted([]int{2, 1}))
+	assert.True(t, util.IsSortedBy([]int{3, 2, 2}, func(a, b int) bool {
+		return a > b
+	}))
+}
+
+func TestMergeSorted(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, util.MergeSorted([]int{1, 4, 7}, []int{}, []int{2, 5}, []int{3, 6}))
+	assert.Equal(t, []int{}, util.MergeSorted[int]())
+
+	byAge := func(a, b testUser) bool {
+		return a.Age < b.Age
+	}
+	assert.Equal(t, []testUser{{"x", 10}, {"a", 20}, {"b", 20}, {"y", 30}}, util.MergeSortedBy(byAge,
+		[]testUser{{"a", 20}, {"y", 30}},
+		[]testUser{{"x", 10}, {"b", 20}},
+	))
+
+	r := rand.New(rand.NewSource(1))
+	var arrs [][]int
+	var all []int
+	for i := 0; i < 10; i++ {
+		arr := make([]int, r.Intn(50))
+		for j := range arr {
+			arr[j] = r.Intn(100)
+		}
+		arrs = append(arrs, util.Sort(arr))
+		all = append(all, arr...)
+	}
+	assert.Equal(t, util.Sort(all), util.MergeSorted(arrs...))
+}
+
+func TestSortedSlice(t *testing.T) {
+	input := []int{5, 1, 3}
+	s := util.NewSortedSlice(input)
+	assert.Equal(t, []int{5, 1, 3}, input)
+	assert.Equal(t, []int{1, 3, 5}, s.Slice())
+
+	s.Insert(4, 0, 3)
+	assert.Equal(t, []int{0, 1, 3, 3, 4, 5}, s.Slice())
+	assert.Equal(t, 6, s.Len())
+	assert.Equal(t, 0, *s.First())
+	assert.Equal(t, 5, *s.Last())
+	assert.Equal(t, 4, *s.Index(4))
+	assert.Nil(t, s.Index(6))
+	assert.Equal(t, 2, s.Search(3))
+	assert.Equal(t, -1, s.Search(2))
+	assert.True(t, s.Contains(4))
+	assert.Equal(t, []int{1, 3, 3}, s.Range(1, 4))
+	assert.Equal(t, []int{}, s.Range(4, 1))
+
+	assert.True(t, s.Remove(3))
+	assert.False(t, s.Remove(2))
+	assert.Equal(t, []int{0, 1, 3, 4, 5}, s.Slice())
+
+	desc := util.NewSortedSliceBy([]string{"a", "c"}, func(a, b string) bool {
+		return a > b
+	}).Insert("b")
+	assert.Equal(t, []string{"c", "b", "a"}, desc.Slice())
+	assert.True(t, util.IsSortedBy(desc.Slice(), func(a, b string) bool {
+		return a > b
+	}))
+}
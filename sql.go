@@ -0,0 +1,234 @@
+package util
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SQLEncoding Slice 在数据库中保存的格式
+type SQLEncoding int
+
+const (
+	// SQLJSON json 数组，例如 ["a","b"]，为默认格式
+	SQLJSON SQLEncoding = iota
+	// SQLCommaText 逗号分隔的文本，例如 a,b，包含逗号或引号的元素按照 CSV 的规则加引号
+	// 空集合保存为空字符串，只有一个空字符串元素时保存为 ""，以便区分
+	SQLCommaText
+	// SQLPostgresArray PostgreSQL 的一维数组字面量，例如 {a,"b c",NULL}
+	SQLPostgresArray
+)
+
+// sqlField 文本格式中元素与字符串的转换规则，复用 CSV 的实现
+var sqlField = csvField{layout: time.RFC3339Nano, prec: -1}
+
+// SetSQLEncoding 设置 Value 与 Scan 使用的格式
+func (s *Slice[T]) SetSQLEncoding(e SQLEncoding) *Slice[T] {
+	s.sqlEncoding = e
+	return s
+}
+
+// Value 实现 driver.Valuer，按照 SetSQLEncoding 设置的格式编码为字符串
+// nil 集合保存为数据库中的 NULL
+func (s *Slice[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	switch s.sqlEncoding {
+	case SQLCommaText:
+		return s.commaText()
+	case SQLPostgresArray:
+		return s.postgresArray()
+	default:
+		b, err := s.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+}
+
+// Scan 实现 sql.Scanner，按照 SetSQLEncoding 设置的格式解码，会覆盖集合中原有的数据
+// 数据库中的 NULL 会得到空集合
+func (s *Slice[T]) Scan(src any) error {
+	var data string
+	switch v := src.(type) {
+	case nil:
+		s.slice = []T{}
+		return nil
+	case []byte:
+		data = string(v)
+	case string:
+		data = v
+	default:
+		return fmt.Errorf("util: cannot scan %T into Slice", src)
+	}
+	switch s.sqlEncoding {
+	case SQLCommaText:
+		return s.scanCommaText(data)
+	case SQLPostgresArray:
+		return s.scanPostgresArray(data)
+	default:
+		return s.UnmarshalJSON([]byte(data))
+	}
+}
+
+func (s *Slice[T]) commaText() (driver.Value, error) {
+	if s.Len() == 0 {
+		return "", nil
+	}
+	record, err := s.texts()
+	if err != nil {
+		return nil, err
+	}
+	if len(record) == 1 && record[0] == "" {
+		// csv 不会给空字段加引号，需要与空集合区分
+		return `""`, nil
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), w.Error()
+}
+
+func (s *Slice[T]) scanCommaText(data string) error {
+	if data == "" {
+		s.slice = []T{}
+		return nil
+	}
+	r := csv.NewReader(strings.NewReader(data))
+	record, err := r.Read()
+	if err != nil {
+		return err
+	}
+	return s.scanTexts(Map(record, func(v string) *string {
+		return &v
+	}))
+}
+
+func (s *Slice[T]) postgresArray() (driver.Value, error) {
+	texts, err := s.texts()
+	if err != nil {
+		return nil, err
+	}
+	elems := Map(texts, func(v string) string {
+		if v != "" && !strings.ContainsAny(v, ",{}\"\\ \t\n\r") && !strings.EqualFold(v, "NULL") {
+			return v
+		}
+		v = strings.ReplaceAll(v, `\`, `\\`)
+		v = strings.ReplaceAll(v, `"`, `\"`)
+		return `"` + v + `"`
+	})
+	return "{" + strings.Join(elems, ",") + "}", nil
+}
+
+func (s *Slice[T]) scanPostgresArray(data string) error {
+	elems, err := parsePostgresArray(data)
+	if err != nil {
+		return err
+	}
+	return s.scanTexts(elems)
+}
+
+// texts 将元素转换为字符串
+func (s *Slice[T]) texts() ([]string, error) {
+	ret := make([]string, 0, s.Len())
+	for i, v := range s.Slice() {
+		text, err := formatCSVValue(reflect.ValueOf(&v).Elem(), sqlField)
+		if err != nil {
+			return nil, fmt.Errorf("util: encode element %d: %w", i, err)
+		}
+		ret = append(ret, text)
+	}
+	return ret, nil
+}
+
+// scanTexts 将字符串转换为元素，nil 表示 NULL，会得到 T 的零值
+func (s *Slice[T]) scanTexts(texts []*string) error {
+	vs := make([]T, len(texts))
+	for i, text := range texts {
+		if text == nil {
+			continue
+		}
+		if err := parseCSVValue(reflect.ValueOf(&vs[i]).Elem(), *text, sqlField); err != nil {
+			return fmt.Errorf("util: decode element %d: %w", i, err)
+		}
+	}
+	s.slice = vs
+	return nil
+}
+
+// parsePostgresArray 解析一维数组字面量，NULL 元素返回 nil
+func parsePostgresArray(data string) ([]*string, error) {
+	data = strings.TrimSpace(data)
+	errInvalid := fmt.Errorf("util: invalid postgres array %q", data)
+	if len(data) < 2 || data[0] != '{' || data[len(data)-1] != '}' {
+		return nil, errInvalid
+	}
+	body := data[1 : len(data)-1]
+	ret := []*string{}
+	if strings.TrimSpace(body) == "" {
+		return ret, nil
+	}
+	for i := 0; i <= len(body); {
+		// 跳过元素前的空白
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		var elem strings.Builder
+		quoted := false
+		if i < len(body) && body[i] == '"' {
+			quoted = true
+			i++
+			for {
+				if i >= len(body) {
+					return nil, errInvalid
+				}
+				c := body[i]
+				if c == '\\' && i+1 < len(body) {
+					elem.WriteByte(body[i+1])
+					i += 2
+					continue
+				}
+				i++
+				if c == '"' {
+					break
+				}
+				elem.WriteByte(c)
+			}
+			for i < len(body) && body[i] == ' ' {
+				i++
+			}
+		} else {
+			for i < len(body) && body[i] != ',' {
+				if body[i] == '{' || body[i] == '}' || body[i] == '"' {
+					// 不支持多维数组
+					return nil, errInvalid
+				}
+				elem.WriteByte(body[i])
+				i++
+			}
+		}
+		if i < len(body) && body[i] != ',' {
+			return nil, errInvalid
+		}
+		i++
+		v := elem.String()
+		if !quoted {
+			v = strings.TrimSpace(v)
+			if strings.EqualFold(v, "NULL") {
+				ret = append(ret, nil)
+				continue
+			}
+		}
+		ret = append(ret, &v)
+	}
+	return ret, nil
+}
@@ -0,0 +1,192 @@
+package util_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+// fakeDriver 只保存最后一次写入的值，查询时返回该值，用于测试 Valuer 与 Scanner
+type fakeDriver struct {
+	value driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c.d, query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.value = args[0]
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{value: s.d.value}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"v"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+var fakeDB = &fakeDriver{}
+
+func init() {
+	sql.Register("util-fake", fakeDB)
+}
+
+func TestSlice_SQLRoundTrip(t *testing.T) {
+	db, err := sql.Open("util-fake", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		encoding util.SQLEncoding
+		stored   string
+	}{
+		{"json", util.SQLJSON, `["a","b c","x,\"y\""]`},
+		{"comma text", util.SQLCommaText, `a,b c,"x,""y"""`},
+		{"postgres array", util.SQLPostgresArray, `{a,"b c","x,\"y\""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := util.NewSlice([]string{"a", "b c", `x,"y"`}).SetSQLEncoding(tt.encoding)
+			_, err := db.Exec("INSERT", in)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stored, fakeDB.value)
+
+			out := util.NewSlice([]string{}).SetSQLEncoding(tt.encoding)
+			assert.NoError(t, db.QueryRow("SELECT").Scan(out))
+			assert.True(t, in.Equal(out))
+		})
+	}
+}
+
+func TestSlice_SQLNil(t *testing.T) {
+	db, err := sql.Open("util-fake", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var s *util.Slice[int]
+	fakeDB.value = "x"
+	_, err = db.Exec("INSERT", s)
+	assert.NoError(t, err)
+	assert.Nil(t, fakeDB.value)
+}
+
+func TestSlice_SQLCommaTextEmptyElement(t *testing.T) {
+	for _, vs := range [][]string{{}, {""}, {"", ""}, {"a", ""}} {
+		in := util.NewSlice(vs).SetSQLEncoding(util.SQLCommaText)
+		v, err := in.Value()
+		assert.NoError(t, err)
+		out := util.NewSlice([]string{"old"}).SetSQLEncoding(util.SQLCommaText)
+		assert.NoError(t, out.Scan(v))
+		assert.Equal(t, vs, out.Slice(), "stored %q", v)
+	}
+	v, _ := util.NewSlice([]string{""}).SetSQLEncoding(util.SQLCommaText).Value()
+	assert.Equal(t, `""`, v)
+}
+
+func TestSlice_Value(t *testing.T) {
+	v, err := util.NewSlice([]int{1, 2}).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", v)
+
+	v, err = util.NewSlice([]int{}).SetSQLEncoding(util.SQLCommaText).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+
+	v, err = util.NewSlice([]string{"", "null", "a\\b"}).SetSQLEncoding(util.SQLPostgresArray).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"","null","a\\b"}`, v)
+
+	v, err = util.NewSlice([]float64{}).SetSQLEncoding(util.SQLPostgresArray).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", v)
+}
+
+func TestSlice_Scan(t *testing.T) {
+	s := util.NewSlice([]int{9})
+	assert.NoError(t, s.Scan(nil))
+	assert.Equal(t, []int{}, s.Slice())
+
+	assert.NoError(t, s.Scan([]byte("[1,2]")))
+	assert.Equal(t, []int{1, 2}, s.Slice())
+
+	s.SetSQLEncoding(util.SQLCommaText)
+	assert.NoError(t, s.Scan("3, 4,5"))
+	assert.Equal(t, []int{3, 4, 5}, s.Slice())
+	assert.NoError(t, s.Scan(""))
+	assert.Equal(t, []int{}, s.Slice())
+	assert.Error(t, s.Scan("1,x"))
+
+	s.SetSQLEncoding(util.SQLPostgresArray)
+	assert.NoError(t, s.Scan("{1, NULL ,3}"))
+	assert.Equal(t, []int{1, 0, 3}, s.Slice())
+	assert.NoError(t, s.Scan("{}"))
+	assert.Equal(t, []int{}, s.Slice())
+	assert.Error(t, s.Scan("{{1,2},{3,4}}"))
+	assert.Error(t, s.Scan("1,2"))
+	assert.Error(t, s.Scan(`{"1}`))
+	assert.Error(t, s.Scan(1))
+
+	strs := util.NewSlice([]string{}).SetSQLEncoding(util.SQLPostgresArray)
+	assert.NoError(t, strs.Scan(`{"NULL",NULL,"a\"b",c d}`))
+	assert.Equal(t, []string{"NULL", "", `a"b`, "c d"}, strs.Slice())
+
+	bools := util.NewSlice([]bool{}).SetSQLEncoding(util.SQLPostgresArray)
+	assert.NoError(t, bools.Scan("{t,f,true}"))
+	assert.Equal(t, []bool{true, false, true}, bools.Slice())
+}
@@ -0,0 +1,201 @@
+package util
+
+import (
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number 数值类型
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Min 返回最小值，数组为空时返回 nil
+func Min[T constraints.Ordered](arr []T) *T {
+	return MinBy(arr, identity[T])
+}
+
+// Max 返回最大值，数组为空时返回 nil
+func Max[T constraints.Ordered](arr []T) *T {
+	return MaxBy(arr, identity[T])
+}
+
+// MinMax 一次遍历同时返回最小值与最大值，数组为空时都返回 nil
+func MinMax[T constraints.Ordered](arr []T) (min, max *T) {
+	if len(arr) == 0 {
+		return nil, nil
+	}
+	lo, hi := arr[0], arr[0]
+	for _, v := range arr[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	return &lo, &hi
+}
+
+// MinBy 返回 key 方法返回值最小的元素，有多个时返回第一个，数组为空时返回 nil
+func MinBy[T any, K constraints.Ordered](arr []T, key func(T) K) *T {
+	if len(arr) == 0 {
+		return nil
+	}
+	ret, min := arr[0], key(arr[0])
+	for _, v := range arr[1:] {
+		if k := key(v); k < min {
+			ret, min = v, k
+		}
+	}
+	return &ret
+}
+
+// MaxBy 返回 key 方法返回值最大的元素，有多个时返回第一个，数组为空时返回 nil
+func MaxBy[T any, K constraints.Ordered](arr []T, key func(T) K) *T {
+	if len(arr) == 0 {
+		return nil
+	}
+	ret, max := arr[0], key(arr[0])
+	for _, v := range arr[1:] {
+		if k := key(v); k > max {
+			ret, max = v, k
+		}
+	}
+	return &ret
+}
+
+// Mean 平均值，数组为空时返回 NaN
+// 使用增量的方式计算，整数求和溢出也不会影响结果
+func Mean[T Number](arr []T) float64 {
+	if len(arr) == 0 {
+		return math.NaN()
+	}
+	// 以第一个元素为偏移量，减少数值较大时的精度损失
+	shift := float64(arr[0])
+	var mean float64
+	for i, v := range arr {
+		mean += (float64(v) - shift - mean) / float64(i+1)
+	}
+	return shift + mean
+}
+
+// Median 中位数，元素个数为偶数时返回中间两个数的平均值，数组为空时返回 NaN
+// 不会修改原数组
+func Median[T Number](arr []T) float64 {
+	return Percentile(arr, 50)
+}
+
+// Mode 众数，出现次数最多的元素，有多个时按照第一次出现的顺序全部返回
+func Mode[T Number](arr []T) []T {
+	counts := CountBy(arr, identity[T])
+	var max int
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+	return Unique(Filter(arr, func(v T) bool {
+		return counts[v] == max
+	}))
+}
+
+// Percentile 百分位数，p 的范围是 [0, 100]，超出范围时取边界值
+// 位于两个元素之间时使用线性插值，数组为空时返回 NaN，不会修改原数组
+func Percentile[T Number](arr []T, p float64) float64 {
+	if len(arr) == 0 {
+		return math.NaN()
+	}
+	sorted := SortBy(append([]T{}, arr...), func(a, b T) bool {
+		return a < b
+	})
+	if p <= 0 {
+		return float64(sorted[0])
+	}
+	if p >= 100 {
+		return float64(sorted[len(sorted)-1])
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	frac := pos - float64(lo)
+	return float64(sorted[lo]) + (float64(sorted[hi])-float64(sorted[lo]))*frac
+}
+
+// Variance 总体方差，数组为空时返回 NaN
+// 使用 Welford 算法计算，避免整数溢出与精度损失
+func Variance[T Number](arr []T) float64 {
+	if len(arr) == 0 {
+		return math.NaN()
+	}
+	shift := float64(arr[0])
+	var mean, m2 float64
+	for i, v := range arr {
+		x := float64(v) - shift
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return m2 / float64(len(arr))
+}
+
+// StdDev 总体标准差，数组为空时返回 NaN
+func StdDev[T Number](arr []T) float64 {
+	return math.Sqrt(Variance(arr))
+}
+
+// HistogramBin 直方图中的一个区间 [Min, Max)，最后一个区间包含 Max
+type HistogramBin struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// Histogram 将最小值到最大值等分为 bins 个区间，统计每个区间的元素个数
+// bins 小于 1 时按 1 处理，NaN 与正负无穷不参与统计，没有可统计的元素时返回空数组
+func Histogram[T Number](arr []T, bins int) []HistogramBin {
+	vs := make([]float64, 0, len(arr))
+	for _, v := range arr {
+		if f := float64(v); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			vs = append(vs, f)
+		}
+	}
+	if len(vs) == 0 {
+		return []HistogramBin{}
+	}
+	if bins < 1 {
+		bins = 1
+	}
+	lo, hi := MinMax(vs)
+	min, max := *lo, *hi
+	width := (max - min) / float64(bins)
+	if math.IsInf(width, 0) {
+		// 最大值与最小值的差超出 float64 的范围
+		width = max/float64(bins) - min/float64(bins)
+	}
+	ret := make([]HistogramBin, bins)
+	for i := range ret {
+		ret[i].Min = min + width*float64(i)
+		ret[i].Max = min + width*float64(i+1)
+	}
+	ret[bins-1].Max = max
+	for _, v := range vs {
+		i := bins - 1
+		if width > 0 {
+			f := (v - min) / width
+			if math.IsInf(f, 0) {
+				f = v/width - min/width
+			}
+			i = int(f)
+		}
+		if i < 0 {
+			i = 0
+		}
+		if i >= bins {
+			i = bins - 1
+		}
+		ret[i].Count++
+	}
+	return ret
+}
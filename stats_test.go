@@ -0,0 +1,110 @@
+package util_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestMinMax(t *testing.T) {
+	assert.Equal(t, 1, *util.Min([]int{3, 1, 2}))
+	assert.Equal(t, 3, *util.Max([]int{3, 1, 2}))
+	assert.Equal(t, "a", *util.Min([]string{"b", "a"}))
+	assert.Nil(t, util.Min([]int{}))
+	assert.Nil(t, util.Max([]float64{}))
+
+	min, max := util.MinMax([]float64{2.5, -1, 7})
+	assert.Equal(t, -1.0, *min)
+	assert.Equal(t, 7.0, *max)
+	min, max = util.MinMax([]float64{})
+	assert.Nil(t, min)
+	assert.Nil(t, max)
+
+	age := func(u testUser) int {
+		return u.Age
+	}
+	assert.Equal(t, testUser{"d", 10}, *util.MinBy(testUsers(), age))
+	assert.Equal(t, testUser{"a", 30}, *util.MaxBy(testUsers(), age))
+	// 有多个时返回第一个
+	assert.Equal(t, testUser{"c", 20}, *util.MinBy([]testUser{{"c", 20}, {"b", 20}}, age))
+	assert.Nil(t, util.MinBy([]testUser{}, age))
+}
+
+func TestMean(t *testing.T) {
+	assert.Equal(t, 2.5, util.Mean([]int{1, 2, 3, 4}))
+	assert.True(t, math.IsNaN(util.Mean([]int{})))
+
+	// 直接求和会溢出
+	big := []int64{math.MaxInt64, math.MaxInt64}
+	assert.InDelta(t, float64(math.MaxInt64), util.Mean(big), 1)
+	assert.Equal(t, 225.0, util.Mean([]uint8{200, 250}))
+}
+
+func TestMedianPercentile(t *testing.T) {
+	arr := []int{5, 1, 4, 2, 3}
+	assert.Equal(t, 3.0, util.Median(arr))
+	assert.Equal(t, []int{5, 1, 4, 2, 3}, arr)
+	assert.Equal(t, 2.5, util.Median([]int{4, 1, 3, 2}))
+	assert.True(t, math.IsNaN(util.Median([]float64{})))
+
+	assert.Equal(t, 1.0, util.Percentile(arr, 0))
+	assert.Equal(t, 5.0, util.Percentile(arr, 100))
+	assert.Equal(t, 5.0, util.Percentile(arr, 120))
+	assert.Equal(t, 2.0, util.Percentile(arr, 25))
+	assert.InDelta(t, 4.6, util.Percentile(arr, 90), 1e-9)
+	assert.True(t, math.IsNaN(util.Percentile([]int{}, 50)))
+}
+
+func TestMode(t *testing.T) {
+	assert.Equal(t, []int{2}, util.Mode([]int{1, 2, 2, 3}))
+	assert.Equal(t, []int{3, 1}, util.Mode([]int{3, 1, 3, 1, 2}))
+	assert.Equal(t, []int{}, util.Mode([]int{}))
+}
+
+func TestVariance(t *testing.T) {
+	arr := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	assert.Equal(t, 4.0, util.Variance(arr))
+	assert.Equal(t, 2.0, util.StdDev(arr))
+	assert.Equal(t, 0.0, util.Variance([]float64{3}))
+	assert.True(t, math.IsNaN(util.StdDev([]int{})))
+
+	// 大数偏移不影响方差
+	shifted := util.Map(arr, func(v int) int64 {
+		return int64(v) + 1e15
+	})
+	assert.InDelta(t, 4.0, util.Variance(shifted), 1e-6)
+}
+
+func TestHistogram(t *testing.T) {
+	bins := util.Histogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}, 5)
+	assert.Equal(t, []int{2, 2, 2, 2, 2}, util.Map(bins, func(b util.HistogramBin) int {
+		return b.Count
+	}))
+	assert.Equal(t, util.HistogramBin{Min: 0, Max: 2, Count: 2}, bins[0])
+	assert.Equal(t, 10.0, bins[4].Max)
+
+	assert.Equal(t, []util.HistogramBin{{Min: 3, Max: 3, Count: 3}}, util.Histogram([]int{3, 3, 3}, 0))
+	assert.Equal(t, []util.HistogramBin{}, util.Histogram([]int{}, 3))
+}
+
+func TestHistogram_NonFinite(t *testing.T) {
+	counts := func(bins []util.HistogramBin) []int {
+		return util.Map(bins, func(b util.HistogramBin) int {
+			return b.Count
+		})
+	}
+	bins := util.Histogram([]float64{1, math.NaN(), 2}, 3)
+	assert.Equal(t, []int{1, 0, 1}, counts(bins))
+	assert.Equal(t, 1.0, bins[0].Min)
+	assert.Equal(t, 2.0, bins[2].Max)
+
+	bins = util.Histogram([]float64{1, math.Inf(1), 2, math.Inf(-1)}, 2)
+	assert.Equal(t, []int{1, 1}, counts(bins))
+	assert.Equal(t, 1.0, bins[0].Min)
+	assert.Equal(t, 2.0, bins[1].Max)
+
+	assert.Equal(t, []util.HistogramBin{}, util.Histogram([]float64{math.NaN(), math.Inf(1)}, 3))
+	assert.Equal(t, []int{1, 1}, counts(util.Histogram([]float64{-math.MaxFloat64, math.MaxFloat64}, 2)))
+}
@@ -0,0 +1,427 @@
+package util
+
+import (
+	"database/sql/driver"
+	"io"
+	"math/rand"
+	"sync"
+)
+
+// SyncSlice 并发安全的集合，方法与 Slice 一致，内部使用读写锁保护
+// 修改类的方法返回原集合用于链式调用，但是链式调用的每一步是单独加锁的，
+// 需要多个步骤作为一个整体时使用 Update
+// 返回的切片与元素指针都是副本，零值为空集合，可以直接使用
+type SyncSlice[T comparable] struct {
+	mu sync.RWMutex
+	s  Slice[T]
+}
+
+// NewSyncSlice 新建一个并发安全的集合，会复制传入的数组
+func NewSyncSlice[T comparable](vs []T) *SyncSlice[T] {
+	return &SyncSlice[T]{s: Slice[T]{slice: append([]T{}, vs...)}}
+}
+
+// write 加写锁执行 f
+func (s *SyncSlice[T]) write(f func(c *Slice[T])) *SyncSlice[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(&s.s)
+	return s
+}
+
+// read 加读锁执行 f
+func (s *SyncSlice[T]) read(f func(c *Slice[T])) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(&s.s)
+}
+
+// syncSliceFrom 以 c 的副本新建一个并发安全的集合，不会复制随机源
+func syncSliceFrom[T comparable](c *Slice[T]) *SyncSlice[T] {
+	ret := &SyncSlice[T]{}
+	ret.s = *c.Copy()
+	ret.s.err = c.err
+	ret.s.rand = nil
+	return ret
+}
+
+func (s *SyncSlice[T]) snapshots(ss []*SyncSlice[T]) []*Slice[T] {
+	return Map(ss, (*SyncSlice[T]).Snapshot)
+}
+
+// Snapshot 返回当前元素的独立副本，之后对集合的修改不会影响副本
+// *rand.Rand 不是并发安全的，所以副本不会共用 SetRand 设置的随机源
+func (s *SyncSlice[T]) Snapshot() *Slice[T] {
+	var ret *Slice[T]
+	s.read(func(c *Slice[T]) {
+		ret = c.Copy()
+		ret.err = c.err
+		ret.rand = nil
+	})
+	return ret
+}
+
+// View 加读锁后使用内部的切片执行 f，避免复制
+// f 中不能修改或者保存传入的切片，也不能调用集合的其他方法
+func (s *SyncSlice[T]) View(f func([]T)) {
+	s.read(func(c *Slice[T]) {
+		f(c.slice)
+	})
+}
+
+// Update 加写锁后原子地更新集合，使用 f 的返回值作为新的元素
+// f 中不能保存传入的切片，也不能调用集合的其他方法
+func (s *SyncSlice[T]) Update(f func([]T) []T) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.slice = f(c.slice)
+	})
+}
+
+// PushIfAbsent 元素不存在时添加到末尾，判断与添加是原子的，返回是否添加
+func (s *SyncSlice[T]) PushIfAbsent(v T) bool {
+	var added bool
+	s.write(func(c *Slice[T]) {
+		if !c.Contains(v) {
+			c.Push(v)
+			added = true
+		}
+	})
+	return added
+}
+
+// Set 使用 vs 的副本覆盖原有数据
+func (s *SyncSlice[T]) Set(vs []T) *SyncSlice[T] {
+	vs = append([]T{}, vs...)
+	return s.write(func(c *Slice[T]) {
+		c.slice = vs
+	})
+}
+
+// Slice 返回集合中数据的副本
+func (s *SyncSlice[T]) Slice() []T {
+	var ret []T
+	s.read(func(c *Slice[T]) {
+		ret = append([]T{}, c.slice...)
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Unique() *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Unique()
+	})
+}
+
+func (s *SyncSlice[T]) Reset() *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.slice = []T{}
+	})
+}
+
+// Each 同 Slice.Each，f 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) Each(f func(T) T) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Each(f)
+	})
+}
+
+// Filter 同 Slice.Filter，f 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) Filter(f func(T) bool) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Filter(f)
+	})
+}
+
+// Reject 同 Slice.Reject，f 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) Reject(f func(T) bool) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Reject(f)
+	})
+}
+
+func (s *SyncSlice[T]) First() *T {
+	var ret *T
+	s.read(func(c *Slice[T]) {
+		ret = copyPtr(c.First())
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Last() *T {
+	var ret *T
+	s.read(func(c *Slice[T]) {
+		ret = copyPtr(c.Last())
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+func (s *SyncSlice[T]) Index(i int) *T {
+	var ret *T
+	s.read(func(c *Slice[T]) {
+		ret = copyPtr(c.Index(i))
+	})
+	return ret
+}
+
+// Copy 返回一个新的并发安全集合，不会复制随机源，见 Snapshot()
+func (s *SyncSlice[T]) Copy() *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot())
+}
+
+func (s *SyncSlice[T]) Merge(ss ...*SyncSlice[T]) *SyncSlice[T] {
+	// 先取得副本再加锁，避免传入自身时死锁
+	others := s.snapshots(ss)
+	return s.write(func(c *Slice[T]) {
+		c.Merge(others...)
+	})
+}
+
+func (s *SyncSlice[T]) MergeSlice(arr []T) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.MergeSlice(arr)
+	})
+}
+
+func (s *SyncSlice[T]) Reverse() *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Reverse()
+	})
+}
+
+// SetRand 设置随机源，使用随机源的方法会加写锁，因为 *rand.Rand 不是并发安全的
+func (s *SyncSlice[T]) SetRand(r *rand.Rand) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.SetRand(r)
+	})
+}
+
+func (s *SyncSlice[T]) Random() *T {
+	var ret *T
+	s.write(func(c *Slice[T]) {
+		ret = copyPtr(c.Random())
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Shuffle() *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Shuffle()
+	})
+}
+
+func (s *SyncSlice[T]) Sample(n int) *SyncSlice[T] {
+	var ret *SyncSlice[T]
+	s.write(func(c *Slice[T]) {
+		ret = syncSliceFrom(c.Sample(n))
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Contains(v T) bool {
+	var ret bool
+	s.read(func(c *Slice[T]) {
+		ret = c.Contains(v)
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) ContainsAll(vs ...T) bool {
+	var ret bool
+	s.read(func(c *Slice[T]) {
+		ret = c.ContainsAll(vs...)
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) ContainsCount(v T) int {
+	var ret int
+	s.read(func(c *Slice[T]) {
+		ret = c.ContainsCount(v)
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Push(vs ...T) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.Push(vs...)
+	})
+}
+
+func (s *SyncSlice[T]) Pop() *T {
+	var ret *T
+	s.write(func(c *Slice[T]) {
+		ret = c.Pop()
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Len() int {
+	var ret int
+	s.read(func(c *Slice[T]) {
+		ret = c.Len()
+	})
+	return ret
+}
+
+func (s *SyncSlice[T]) Equal(s2 *SyncSlice[T]) bool {
+	return s.Snapshot().Equal(s2.Snapshot())
+}
+
+func (s *SyncSlice[T]) JSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s *SyncSlice[T]) JSONString() (string, error) {
+	return s.Snapshot().JSONString()
+}
+
+func (s *SyncSlice[T]) Pretty() string {
+	return s.Snapshot().Pretty()
+}
+
+func (s *SyncSlice[T]) MarshalJSON() ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	s.read(func(c *Slice[T]) {
+		b, err = c.MarshalJSON()
+	})
+	return b, err
+}
+
+func (s *SyncSlice[T]) UnmarshalJSON(data []byte) error {
+	var err error
+	s.write(func(c *Slice[T]) {
+		err = c.UnmarshalJSON(data)
+	})
+	return err
+}
+
+func (s *SyncSlice[T]) Diff(c2 *SyncSlice[T]) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().Diff(c2.Snapshot()))
+}
+
+// SortBy 同 Slice.SortBy，less 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) SortBy(less func(a, b T) bool) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.SortBy(less)
+	})
+}
+
+// SortStable 同 Slice.SortStable，less 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) SortStable(less func(a, b T) bool) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.SortStable(less)
+	})
+}
+
+func (s *SyncSlice[T]) Partition(f func(T) bool) (*SyncSlice[T], *SyncSlice[T]) {
+	yes, no := s.Snapshot().Partition(f)
+	return syncSliceFrom(yes), syncSliceFrom(no)
+}
+
+// Chunk 同 Slice.Chunk，但是每一组都是独立的副本
+func (s *SyncSlice[T]) Chunk(size int) []*SyncSlice[T] {
+	return Map(s.Snapshot().Chunk(size), syncSliceFrom[T])
+}
+
+// Windows 同 Slice.Windows，但是每个窗口都是独立的副本
+func (s *SyncSlice[T]) Windows(size, step int) []*SyncSlice[T] {
+	return Map(s.Snapshot().Windows(size, step), syncSliceFrom[T])
+}
+
+func (s *SyncSlice[T]) Reduce(init T, f func(acc T, v T) T) T {
+	return s.Snapshot().Reduce(init, f)
+}
+
+func (s *SyncSlice[T]) Accumulate(init T, f func(acc T, v T) T) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().Accumulate(init, f))
+}
+
+func (s *SyncSlice[T]) Err() error {
+	var ret error
+	s.read(func(c *Slice[T]) {
+		ret = c.Err()
+	})
+	return ret
+}
+
+// EachErr 同 Slice.EachErr，f 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) EachErr(f func(T) (T, error), mode ...ErrMode) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.EachErr(f, mode...)
+	})
+}
+
+// FilterErr 同 Slice.FilterErr，f 在持有写锁时执行，不能调用集合的方法，否则会死锁
+func (s *SyncSlice[T]) FilterErr(f func(T) (bool, error), mode ...ErrMode) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.FilterErr(f, mode...)
+	})
+}
+
+func (s *SyncSlice[T]) MapErr(f func(T) (T, error), mode ...ErrMode) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().MapErr(f, mode...))
+}
+
+func (s *SyncSlice[T]) ToSet() *Set[T] {
+	return s.Snapshot().ToSet()
+}
+
+func (s *SyncSlice[T]) Intersect(c2 *SyncSlice[T]) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().Intersect(c2.Snapshot()))
+}
+
+func (s *SyncSlice[T]) Union(ss ...*SyncSlice[T]) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().Union(s.snapshots(ss)...))
+}
+
+func (s *SyncSlice[T]) SymmetricDiff(c2 *SyncSlice[T]) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().SymmetricDiff(c2.Snapshot()))
+}
+
+func (s *SyncSlice[T]) Map(f func(T) T) *SyncSlice[T] {
+	return syncSliceFrom(s.Snapshot().Map(f))
+}
+
+func (s *SyncSlice[T]) WriteCSV(w io.Writer, opts ...CSVOptions) error {
+	return s.Snapshot().WriteCSV(w, opts...)
+}
+
+func (s *SyncSlice[T]) WriteNDJSON(w io.Writer) error {
+	return s.Snapshot().WriteNDJSON(w)
+}
+
+func (s *SyncSlice[T]) SetSQLEncoding(e SQLEncoding) *SyncSlice[T] {
+	return s.write(func(c *Slice[T]) {
+		c.SetSQLEncoding(e)
+	})
+}
+
+// Value 实现 driver.Valuer，nil 集合保存为数据库中的 NULL
+func (s *SyncSlice[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var (
+		v   driver.Value
+		err error
+	)
+	s.read(func(c *Slice[T]) {
+		v, err = c.Value()
+	})
+	return v, err
+}
+
+func (s *SyncSlice[T]) Scan(src any) error {
+	var err error
+	s.write(func(c *Slice[T]) {
+		err = c.Scan(src)
+	})
+	return err
+}
@@ -0,0 +1,130 @@
+package util_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	util "github.com/zhan3333/goutil"
+)
+
+func TestSyncSlice(t *testing.T) {
+	input := []int{3, 1, 2}
+	s := util.NewSyncSlice(input)
+	input[0] = 100
+	assert.Equal(t, []int{3, 1, 2}, s.Slice())
+
+	assert.True(t, s.PushIfAbsent(4))
+	assert.False(t, s.PushIfAbsent(3))
+	assert.Equal(t, []int{3, 1, 2, 4}, s.Slice())
+
+	// 返回的切片与指针都是副本
+	s.Slice()[0] = 100
+	*s.First() = 100
+	assert.Equal(t, 3, *s.First())
+	assert.Equal(t, 4, *s.Last())
+	assert.Nil(t, s.Index(10))
+
+	snap := s.Snapshot()
+	s.Push(5)
+	assert.Equal(t, []int{3, 1, 2, 4}, snap.Slice())
+
+	s.Update(func(arr []int) []int {
+		sort.Ints(arr)
+		return arr[:3]
+	})
+	assert.Equal(t, []int{1, 2, 3}, s.Slice())
+
+	assert.Equal(t, 3, *s.Pop())
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Merge(s).Equal(util.NewSyncSlice([]int{1, 2, 1, 2})))
+	assert.Equal(t, []int{1, 2}, s.Unique().Slice())
+	assert.Equal(t, []int{2, 4}, s.Map(func(v int) int { return v * 2 }).Slice())
+	assert.Equal(t, []int{1, 2}, s.Slice())
+
+	chunks := s.Chunk(1)
+	chunks[0].Push(9)
+	assert.Equal(t, []int{1, 2}, s.Slice())
+
+	b, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2]", string(b))
+
+	var zero util.SyncSlice[int]
+	assert.True(t, zero.Empty())
+	assert.Nil(t, json.Unmarshal([]byte("[1,2]"), &zero))
+	assert.Equal(t, []int{1, 2}, zero.Slice())
+}
+
+func TestSyncSlice_Race(t *testing.T) {
+	s := util.NewSyncSlice([]int{})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				s.PushIfAbsent(i)
+				s.Push(-1)
+				s.Contains(i)
+				s.Snapshot()
+				s.Random()
+				s.View(func(arr []int) {
+					_ = len(arr)
+				})
+				s.Update(func(arr []int) []int {
+					return util.Reject(arr, func(v int) bool {
+						return v == -1
+					})
+				})
+				s.Merge(s)
+				s.Unique()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// 每个值只会被添加一次
+	got := s.Slice()
+	sort.Ints(got)
+	want := make([]int, 500)
+	for i := range want {
+		want[i] = i
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestSyncSlice_NilValue(t *testing.T) {
+	var s *util.SyncSlice[int]
+	v, err := s.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestSyncSlice_CopyRandRace(t *testing.T) {
+	s := util.NewSyncSlice([]int{1, 2, 3, 4, 5}).SetRand(rand.New(rand.NewSource(1)))
+	derived := []*util.SyncSlice[int]{s.Copy(), s.Sample(3), s.Map(func(v int) int { return v })}
+	snap := s.Snapshot()
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				f()
+			}
+		}()
+	}
+	run(func() { s.Shuffle() })
+	for _, c := range derived {
+		c := c
+		run(func() { c.Shuffle() })
+	}
+	run(func() { snap.Shuffle() })
+	wg.Wait()
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, s.Slice())
+}
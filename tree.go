@@ -29,3 +29,126 @@ func (n *TreeNode[T]) SetLeft(val T) {
 func (n *TreeNode[T]) SetRight(val T) {
 	n.right = &TreeNode[T]{val: val}
 }
+
+// SetLeftNode 直接挂载已有的节点作为左子树，传入 nil 会移除左子树
+func (n *TreeNode[T]) SetLeftNode(node *TreeNode[T]) {
+	n.left = node
+}
+
+// SetRightNode 直接挂载已有的节点作为右子树，传入 nil 会移除右子树
+func (n *TreeNode[T]) SetRightNode(node *TreeNode[T]) {
+	n.right = node
+}
+
+// PreOrder 前序遍历(根-左-右)，f 返回 false 时停止遍历
+func (n *TreeNode[T]) PreOrder(f func(v T) bool) {
+	if n == nil {
+		return
+	}
+	s := NewStack[*TreeNode[T]]()
+	s.Push(n)
+	for !s.Empty() {
+		node := *s.Pop()
+		if !f(node.val) {
+			return
+		}
+		// 先压入右子树，保证左子树先出栈
+		if node.right != nil {
+			s.Push(node.right)
+		}
+		if node.left != nil {
+			s.Push(node.left)
+		}
+	}
+}
+
+// InOrder 中序遍历(左-根-右)，f 返回 false 时停止遍历
+func (n *TreeNode[T]) InOrder(f func(v T) bool) {
+	s := NewStack[*TreeNode[T]]()
+	cur := n
+	for cur != nil || !s.Empty() {
+		for cur != nil {
+			s.Push(cur)
+			cur = cur.left
+		}
+		cur = *s.Pop()
+		if !f(cur.val) {
+			return
+		}
+		cur = cur.right
+	}
+}
+
+// PostOrder 后序遍历(左-右-根)，f 返回 false 时停止遍历
+func (n *TreeNode[T]) PostOrder(f func(v T) bool) {
+	s := NewStack[*TreeNode[T]]()
+	var last *TreeNode[T]
+	cur := n
+	for cur != nil || !s.Empty() {
+		if cur != nil {
+			s.Push(cur)
+			cur = cur.left
+			continue
+		}
+		top := *s.Top()
+		// 右子树存在且还没有访问过，先访问右子树
+		if top.right != nil && top.right != last {
+			cur = top.right
+			continue
+		}
+		if !f(top.val) {
+			return
+		}
+		last = *s.Pop()
+	}
+}
+
+// LevelOrder 层序遍历，从上到下、从左到右，f 返回 false 时停止遍历
+func (n *TreeNode[T]) LevelOrder(f func(v T) bool) {
+	if n == nil {
+		return
+	}
+	q := NewQueue[*TreeNode[T]]()
+	q.Push(n)
+	for q.Len() > 0 {
+		node := *q.Pop()
+		if !f(node.val) {
+			return
+		}
+		if node.left != nil {
+			q.Push(node.left)
+		}
+		if node.right != nil {
+			q.Push(node.right)
+		}
+	}
+}
+
+// PreOrderSlice 以切片的形式返回前序遍历的结果
+func (n *TreeNode[T]) PreOrderSlice() []T {
+	return collectTree(n.PreOrder)
+}
+
+// InOrderSlice 以切片的形式返回中序遍历的结果
+func (n *TreeNode[T]) InOrderSlice() []T {
+	return collectTree(n.InOrder)
+}
+
+// PostOrderSlice 以切片的形式返回后序遍历的结果
+func (n *TreeNode[T]) PostOrderSlice() []T {
+	return collectTree(n.PostOrder)
+}
+
+// LevelOrderSlice 以切片的形式返回层序遍历的结果
+func (n *TreeNode[T]) LevelOrderSlice() []T {
+	return collectTree(n.LevelOrder)
+}
+
+func collectTree[T any](walk func(f func(v T) bool)) []T {
+	ret := []T{}
+	walk(func(v T) bool {
+		ret = append(ret, v)
+		return true
+	})
+	return ret
+}
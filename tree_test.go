@@ -17,3 +17,72 @@ func TestNewTree(t *testing.T) {
 	assert.NotNil(t, tree.Right())
 	assert.Equal(t, 3, tree.Right().Val())
 }
+
+// newTestTree 返回测试用的树:
+//
+//	    1
+//	   / \
+//	  2   3
+//	 / \   \
+//	4   5   6
+func newTestTree() *util.TreeNode[int] {
+	tree := util.NewTree(1)
+	tree.SetLeft(2)
+	tree.SetRight(3)
+	tree.Left().SetLeft(4)
+	tree.Left().SetRight(5)
+	tree.Right().SetRight(6)
+	return tree
+}
+
+func TestTreeNode_Traversal(t *testing.T) {
+	tree := newTestTree()
+	assert.Equal(t, []int{1, 2, 4, 5, 3, 6}, tree.PreOrderSlice())
+	assert.Equal(t, []int{4, 2, 5, 1, 3, 6}, tree.InOrderSlice())
+	assert.Equal(t, []int{4, 5, 2, 6, 3, 1}, tree.PostOrderSlice())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, tree.LevelOrderSlice())
+
+	var empty *util.TreeNode[int]
+	assert.Equal(t, []int{}, empty.PreOrderSlice())
+	assert.Equal(t, []int{}, empty.InOrderSlice())
+	assert.Equal(t, []int{}, empty.PostOrderSlice())
+	assert.Equal(t, []int{}, empty.LevelOrderSlice())
+}
+
+func TestTreeNode_TraversalStop(t *testing.T) {
+	tree := newTestTree()
+	walks := map[string]func(f func(int) bool){
+		"pre":   tree.PreOrder,
+		"in":    tree.InOrder,
+		"post":  tree.PostOrder,
+		"level": tree.LevelOrder,
+	}
+	wants := map[string][]int{
+		"pre":   {1, 2, 4},
+		"in":    {4, 2, 5},
+		"post":  {4, 5, 2},
+		"level": {1, 2, 3},
+	}
+	for name, walk := range walks {
+		t.Run(name, func(t *testing.T) {
+			var got []int
+			walk(func(v int) bool {
+				got = append(got, v)
+				return len(got) < 3
+			})
+			assert.Equal(t, wants[name], got)
+		})
+	}
+}
+
+func TestTreeNode_SetNode(t *testing.T) {
+	tree := util.NewTree(2)
+	left := util.NewTree(1)
+	tree.SetLeftNode(left)
+	tree.SetRightNode(util.NewTree(3))
+	assert.Same(t, left, tree.Left())
+	assert.Equal(t, []int{1, 2, 3}, tree.InOrderSlice())
+
+	tree.SetLeftNode(nil)
+	assert.Nil(t, tree.Left())
+}